@@ -0,0 +1,77 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Base struct{}
+
+// BaseOptions picks how the number is written
+type BaseOptions struct {
+	// ToBase is the base the number is written in, from 2 to 36
+	ToBase int `option:"to-base"`
+	// Bits is the width the number is padded to (and wrapped at for negative
+	// numbers), 0 means as wide as it needs to be
+	Bits int `option:"bits"`
+	// Group is the amount of digits in each group, 0 means no grouping and -1
+	// the usual amount for the base
+	Group int `option:"group"`
+}
+
+func NewBase() Format {
+	return &Base{}
+}
+
+// Options defaults to hex since that's the most common case by far
+func (b *Base) Options() Options {
+	return &BaseOptions{ToBase: 16, Group: -1}
+}
+
+func (b *Base) GetParsers() []parsers.Parser {
+	return []parsers.Parser{parsers.NewBase(16, 0, -1)}
+}
+
+func (b *Base) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*BaseOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	if o.ToBase < 2 || o.ToBase > 36 {
+		return "", parsers.ErrBadBase
+	}
+
+	p := parsers.NewBase(o.ToBase, o.Bits, o.Group)
+
+	if ok, _ := p.CanParseFromMachine(input); direction == FromMachine && ok {
+		return p.DoFromMachine(input)
+	}
+
+	if ok, _ := p.CanParseIntoMachine(input); direction == IntoMachine && ok {
+		return p.DoIntoMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
+
+// Detect is sure about literals with a base prefix (ie: 0x1f) going into a
+// plain number, they're already written in a base so writing them in one again
+// mostly repeats them. Numbers with a leading zero are more likely file modes
+// (ie: 0755) than numbers to write in hex
+func (b *Base) Detect(direction Direction, input string) Confidence {
+	prefixed := regexp.MustCompile(`^[-+]?0[xXbBoO][0-9a-fA-F_]+$`).MatchString(input)
+	if direction == IntoMachine {
+		if prefixed {
+			return HighConfidence
+		}
+		return MediumConfidence
+	}
+
+	if prefixed || regexp.MustCompile(`^0[0-9]+$`).MatchString(input) {
+		return LowConfidence
+	}
+	return MediumConfidence
+}
@@ -0,0 +1,74 @@
+package format
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestBaseFormatRun(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		// Should default to hex
+		{FromMachine, "32767", io.ParseCliArgs([]string{""}), "0x7fff", nil},
+		{FromMachine, "3735928559", io.ParseCliArgs([]string{""}), "0xdead_beef", nil},
+		// Should accept a target base
+		{FromMachine, "165", io.ParseCliArgs([]string{"--to-base", "2"}), "0b1010_0101", nil},
+		{FromMachine, "493", io.ParseCliArgs([]string{"--to-base", "8"}), "0o755", nil},
+		{FromMachine, "0x7fff", io.ParseCliArgs([]string{"--to-base", "2"}), "0b111_1111_1111_1111", nil},
+		{FromMachine, "1295", io.ParseCliArgs([]string{"--to-base", "36"}), "36#zz", nil},
+		{FromMachine, "165", io.ParseCliArgs([]string{"--to-base", "99"}), "", parsers.ErrBadBase},
+		{FromMachine, "165", io.ParseCliArgs([]string{"--to-base", "hex"}), "", ErrBadOption},
+		// Should pad to the bit width
+		{FromMachine, "5", io.ParseCliArgs([]string{"--to-base", "2", "--bits", "8"}), "0b0000_0101", nil},
+		// Should allow turning off grouping
+		{FromMachine, "3735928559", io.ParseCliArgs([]string{"--group", "0"}), "0xdeadbeef", nil},
+		// Into
+		{IntoMachine, "0x7fff", io.ParseCliArgs([]string{""}), "32767", nil},
+		{IntoMachine, "0o755", io.ParseCliArgs([]string{""}), "493", nil},
+		{IntoMachine, "0b1010_0101", io.ParseCliArgs([]string{""}), "165", nil},
+		{IntoMachine, "0xff", io.ParseCliArgs([]string{"--bits", "8"}), "-1", nil},
+		{IntoMachine, "1994", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+	}
+
+	base := NewBase()
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(base, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%t` ; got `%t`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestBaseDetect(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		out       Confidence
+	}{
+		{FromMachine, "1994", MediumConfidence},
+		{FromMachine, "0755", LowConfidence},
+		{FromMachine, "0x1f", LowConfidence},
+		{IntoMachine, "0x1f", HighConfidence},
+		{IntoMachine, "0b101", HighConfidence},
+	}
+
+	for i, tt := range tests {
+		if got := Detect(NewBase(), tt.direction, tt.input); got != tt.out {
+			t.Errorf("Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.input, tt.out, got)
+		}
+	}
+}
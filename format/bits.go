@@ -0,0 +1,111 @@
+package format
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Bits struct {
+	// schemas is the directory with the user's schemas, they're picked over
+	// the builtin ones with the same name
+	schemas string
+}
+
+// BitsOptions picks the names of the bits
+type BitsOptions struct {
+	// Schema is a file with the names of the bits, the name of one in the
+	// config directory or one of the builtin ones (ie: tcp, open)
+	Schema string `option:"schema"`
+}
+
+func NewBits() Format {
+	schemas := ""
+	if dir, err := os.UserConfigDir(); err == nil {
+		schemas = filepath.Join(dir, "human", "bits")
+	}
+	return &Bits{schemas: schemas}
+}
+
+func (b *Bits) GetParsers() []parsers.Parser {
+	return []parsers.Parser{parsers.NewBits(nil)}
+}
+
+func (b *Bits) Options() Options {
+	return &BitsOptions{}
+}
+
+func (b *Bits) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*BitsOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	var schema *parsers.BitSchema
+	if o.Schema != "" {
+		var err error
+		if schema, err = b.loadSchema(o.Schema); err != nil {
+			return "", err
+		}
+	}
+
+	p := parsers.NewBits(schema)
+	if ok, _ := p.CanParseFromMachine(input); direction == FromMachine && ok {
+		return p.DoFromMachine(input)
+	}
+
+	// Without a schema the flags tell which one they're from
+	if direction == IntoMachine && schema == nil {
+		for _, name := range parsers.BuiltinBitSchemaNames() {
+			s, _ := parsers.BuiltinBitSchema(name)
+			if out, err := parsers.NewBits(s).DoIntoMachine(input); err == nil {
+				return out, nil
+			}
+		}
+		return "", parsers.ErrUnparsable
+	}
+
+	if ok, err := p.CanParseIntoMachine(input); direction == IntoMachine && ok {
+		return p.DoIntoMachine(input)
+	} else if direction == IntoMachine && err != parsers.ErrUnparsable {
+		return "", err
+	}
+
+	return "", parsers.ErrUnparsable
+}
+
+// loadSchema reads the schema from a file when there's one with that name
+// (or path), otherwise it's one of the builtin schemas
+func (b *Bits) loadSchema(name string) (*parsers.BitSchema, error) {
+	for _, path := range []string{name, filepath.Join(b.schemas, name)} {
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			continue
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return parsers.ParseBitSchema(string(data))
+	}
+	return parsers.BuiltinBitSchema(name)
+}
+
+// Detect is only somewhat sure about numbers written in hex or binary (or
+// after a `key=`), decimals are far more likely to just be numbers. Flags
+// are never anything else
+func (b *Bits) Detect(direction Direction, input string) Confidence {
+	if direction == IntoMachine {
+		return HighConfidence
+	}
+	if regexp.MustCompile(`^(?:[A-Za-z_][A-Za-z0-9_.-]*[=:]\s*)?0[xXbB][0-9a-fA-F_]+$`).MatchString(input) {
+		return MediumConfidence
+	}
+	if regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*[=:]\s*[0-9]+$`).MatchString(input) {
+		return MediumConfidence
+	}
+	return NoConfidence
+}
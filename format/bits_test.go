@@ -0,0 +1,73 @@
+package format
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestBitsFormatRun(t *testing.T) {
+	dir := t.TempDir()
+	ioutil.WriteFile(filepath.Join(dir, "perms"), []byte("EXEC = 1\nWRITE = 2\nREAD = 4\n"), 0644)
+	file := filepath.Join(t.TempDir(), "job.flags")
+	ioutil.WriteFile(file, []byte("QUEUED = 1\nRUNNING = 2\n"), 0644)
+
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		{FromMachine, "0x12", io.ParseCliArgs([]string{"--schema", "tcp"}), "SYN|ACK", nil},
+		{FromMachine, "6", io.ParseCliArgs([]string{"--schema=perms"}), "WRITE|READ", nil},
+		{FromMachine, "3", io.ParseCliArgs([]string{"--schema=" + file}), "QUEUED|RUNNING", nil},
+		{FromMachine, "3", io.ParseCliArgs([]string{"--schema=nope"}), "", parsers.ErrUnknownBitSchema},
+		{FromMachine, "nope", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{IntoMachine, "SYN|ACK", io.ParseCliArgs([]string{"--schema=tcp"}), "18", nil},
+		{IntoMachine, "SYN|FOO", io.ParseCliArgs([]string{"--schema=tcp"}), "", parsers.ErrUnknownFlag},
+		// Without a schema the builtin ones are tried
+		{IntoMachine, "SYN|ACK", io.ParseCliArgs([]string{""}), "18", nil},
+		{IntoMachine, "IN_CREATE|IN_ISDIR", io.ParseCliArgs([]string{""}), "1073742080", nil},
+		{IntoMachine, "SYN|FOO", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+	}
+
+	b := &Bits{schemas: dir}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(b, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%v` ; got `%v`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestBitsDetect(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		out       Confidence
+	}{
+		{FromMachine, "0x1a", MediumConfidence},
+		{FromMachine, "flags=0x1a", MediumConfidence},
+		{FromMachine, "flags=26", MediumConfidence},
+		{FromMachine, "26", NoConfidence},
+		{IntoMachine, "SYN|ACK", HighConfidence},
+	}
+
+	b := &Bits{}
+	for i, tt := range tests {
+		if got := b.Detect(tt.direction, tt.input); got != tt.out {
+			t.Errorf("Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.input, tt.out, got)
+		}
+	}
+}
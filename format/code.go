@@ -0,0 +1,61 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Code struct{}
+
+// CodeOptions picks the table codes are looked up in
+type CodeOptions struct {
+	// Kind is the table, ie: http, exit, errno or signal. Empty means all of
+	// them
+	Kind string `option:"kind" choices:"http,errno,signal,exit"`
+}
+
+func NewCode() Format {
+	return &Code{}
+}
+
+func (c *Code) GetParsers() []parsers.Parser {
+	p, _ := parsers.NewCode("")
+	return []parsers.Parser{p}
+}
+
+func (c *Code) Options() Options {
+	return &CodeOptions{}
+}
+
+func (c *Code) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*CodeOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	p, err := parsers.NewCode(o.Kind)
+	if err != nil {
+		return "", err
+	}
+
+	if ok, _ := p.CanParseFromMachine(input); direction == FromMachine && ok {
+		return p.DoFromMachine(input)
+	}
+
+	if ok, _ := p.CanParseIntoMachine(input); direction == IntoMachine && ok {
+		return p.DoIntoMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
+
+// Detect is sure about names and descriptions, but almost any small number is
+// in one of the tables so numbers are only offered after everything else
+func (c *Code) Detect(direction Direction, input string) Confidence {
+	if direction == FromMachine && regexp.MustCompile(`^[0-9]+$`).MatchString(input) {
+		return LowConfidence
+	}
+	return HighConfidence
+}
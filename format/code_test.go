@@ -0,0 +1,41 @@
+package format
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestCodeFormatRun(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		{FromMachine, "137", io.ParseCliArgs([]string{""}), "exit 137 = killed by SIGKILL (9)", nil},
+		{FromMachine, "9", io.ParseCliArgs([]string{"--kind=signal"}), "signal 9 = SIGKILL (killed)", nil},
+		{FromMachine, "9", io.ParseCliArgs([]string{"--kind=windows"}), "", ErrBadOption},
+		{FromMachine, "hello", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{IntoMachine, "SIGTERM", io.ParseCliArgs([]string{""}), "15", nil},
+		{IntoMachine, "not found", io.ParseCliArgs([]string{"--kind=http"}), "404", nil},
+		{IntoMachine, "137", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+	}
+
+	code := NewCode()
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(code, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%v` ; got `%v`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
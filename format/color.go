@@ -0,0 +1,75 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Color struct{}
+
+// ColorOptions picks how colors are written
+type ColorOptions struct {
+	// To is the notation colors are written in with --into, ie: hex, rgb, hsl
+	To string `option:"to"`
+	// On is the background the contrast is checked against
+	On string `option:"on"`
+	// Swatch shows the color with terminal escape codes, only callers that know
+	// the output goes to a terminal should turn it on
+	Swatch bool `option:"swatch"`
+}
+
+func NewColor() Format {
+	return &Color{}
+}
+
+func (c *Color) GetParsers() []parsers.Parser {
+	p, _ := parsers.NewColor("", "", false)
+	return []parsers.Parser{p}
+}
+
+func (c *Color) Options() Options {
+	return &ColorOptions{}
+}
+
+func (c *Color) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*ColorOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	p, err := parsers.NewColor(o.To, o.On, o.Swatch)
+	if err != nil {
+		return "", err
+	}
+
+	if ok, _ := p.CanParseFromMachine(input); direction == FromMachine && ok {
+		return p.DoFromMachine(input)
+	}
+
+	if ok, _ := p.CanParseIntoMachine(input); direction == IntoMachine && ok {
+		return p.DoIntoMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
+
+// Detect is sure about colors written with a # or a CSS function, names are
+// only converted into hex and only CSS's named colors count. Bare numbers, hex
+// digits and other words are far more likely to be something else
+func (c *Color) Detect(direction Direction, input string) Confidence {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if direction == IntoMachine {
+		if parsers.IsColorName(input) {
+			return HighConfidence
+		}
+		return NoConfidence
+	}
+
+	if regexp.MustCompile(`^[0-9]+$|^[0-9a-f]{6}([0-9a-f]{2})?$`).MatchString(input) || regexp.MustCompile(`^[a-z]+$`).MatchString(input) {
+		return NoConfidence
+	}
+	return HighConfidence
+}
@@ -0,0 +1,87 @@
+package format
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestColorFormatRun(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		{FromMachine, "#777 on #fff", io.ParseCliArgs([]string{""}), "contrast:  4.48:1\nAA:        fail (normal text), pass (large text)\nAAA:       fail (normal text), fail (large text)", nil},
+		{FromMachine, "#000", io.ParseCliArgs([]string{"--on=white", "--swatch=false"}), "contrast:  21:1\nAA:        pass (normal text), pass (large text)\nAAA:       pass (normal text), pass (large text)", nil},
+		{FromMachine, "#000", io.ParseCliArgs([]string{"--on=nope"}), "", parsers.ErrNotAColor},
+		{FromMachine, "hello", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{IntoMachine, "darkorange", io.ParseCliArgs([]string{""}), "#ff8c00", nil},
+		{IntoMachine, "darkorange", io.ParseCliArgs([]string{"--to=rgb"}), "rgb(255, 140, 0)", nil},
+		{IntoMachine, "darkorange", io.ParseCliArgs([]string{"--to=cmyk"}), "", parsers.ErrUnknownColorNotation},
+	}
+
+	color := NewColor()
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(color, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%v` ; got `%v`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestColorDetect(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		out       Confidence
+	}{
+		{FromMachine, "#ff8800", HighConfidence},
+		{FromMachine, "rgb(255, 136, 0)", HighConfidence},
+		{FromMachine, "208", NoConfidence},
+		{FromMachine, "deadbeef", NoConfidence},
+		{IntoMachine, "darkorange", HighConfidence},
+		{IntoMachine, "DarkOrange", HighConfidence},
+		{IntoMachine, "deadbeef", NoConfidence},
+		{IntoMachine, "hello", NoConfidence},
+		{IntoMachine, "#ff8800", NoConfidence},
+	}
+
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Detect(NewColor(), tt.direction, tt.input); got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%d` ; got `%d`", i, tt.input, tt.out, got)
+			}
+		})
+	}
+}
+
+func TestColorSwatch(t *testing.T) {
+	tests := []struct {
+		args   io.CliArgs
+		escape bool
+	}{
+		// The swatch is only shown when asked for, the output might not be a
+		// terminal
+		{io.ParseCliArgs([]string{""}), false},
+		{io.ParseCliArgs([]string{"--swatch=true"}), true},
+	}
+
+	for i, tt := range tests {
+		got, _ := run(NewColor(), FromMachine, "#000", tt.args)
+		if strings.Contains(got, "\x1b[") != tt.escape {
+			t.Errorf("Case %d: Given = `#000` Args = `%v+` ; want escape codes `%t` ; got `%q`", i, tt.args, tt.escape, got)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Cron struct{}
+
+// CronOptions is empty, schedules are always written the same way
+type CronOptions struct{}
+
+func NewCron() Format {
+	return &Cron{}
+}
+
+func (c *Cron) GetParsers() []parsers.Parser {
+	return []parsers.Parser{parsers.NewCron()}
+}
+
+func (c *Cron) Options() Options {
+	return &CronOptions{}
+}
+
+func (c *Cron) Run(direction Direction, input string, opts Options) (string, error) {
+	if _, ok := opts.(*CronOptions); !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	p := parsers.NewCron()
+
+	if ok, _ := p.CanParseFromMachine(input); direction == FromMachine && ok {
+		return p.DoFromMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
+
+// Detect is sure about the 5 fields of a cron schedule, nothing else is
+// written like that
+func (c *Cron) Detect(direction Direction, input string) Confidence {
+	if direction == FromMachine && len(strings.Fields(input)) == 5 {
+		return HighConfidence
+	}
+	return NoConfidence
+}
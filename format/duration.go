@@ -0,0 +1,58 @@
+package format
+
+import (
+	"fmt"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Duration struct{}
+
+// DurationOptions picks how durations are written and read
+type DurationOptions struct {
+	// Unit is the unit of the machine number, seconds by default
+	Unit string `option:"unit" choices:"s,ms,us,µs,ns"`
+	// Style is short (the default) for symbols, long for names and approx to
+	// round to the largest unit
+	Style string `option:"style" choices:"short,long,approx"`
+	// Words is the same as the long style, just like with numbers
+	Words bool `flag:"w"`
+}
+
+func NewDuration() Format {
+	return &Duration{}
+}
+
+func (d *Duration) GetParsers() []parsers.Parser {
+	return []parsers.Parser{parsers.NewDuration("s", "short"), parsers.NewDuration("s", "long"), parsers.NewDuration("s", "approx")}
+}
+
+func (d *Duration) Options() Options {
+	return &DurationOptions{}
+}
+
+func (d *Duration) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*DurationOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	// Words are asked for with `-w` just like with numbers, `--style` allows
+	// picking any of them
+	style := o.Style
+	if o.Words && style == "" {
+		style = "long"
+	}
+
+	p := parsers.NewDuration(o.Unit, style)
+
+	if ok, _ := p.CanParseFromMachine(input); direction == FromMachine && ok {
+		return p.DoFromMachine(input)
+	}
+
+	if ok, _ := p.CanParseIntoMachine(input); direction == IntoMachine && ok {
+		return p.DoIntoMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
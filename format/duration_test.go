@@ -0,0 +1,45 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestDurationFormatRun(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		// Should default to seconds and the short style
+		{FromMachine, "7384", io.ParseCliArgs([]string{""}), "2h 3m 4s", nil},
+		{FromMachine, "7384000", io.ParseCliArgs([]string{"--unit", "ms"}), "2h 3m 4s", nil},
+		{FromMachine, "7380", io.ParseCliArgs([]string{"-w"}), "2 hours, 3 minutes", nil},
+		{FromMachine, "7380", io.ParseCliArgs([]string{"--style", "long"}), "2 hours, 3 minutes", nil},
+		{FromMachine, "7384", io.ParseCliArgs([]string{"--style", "approx"}), "about 2 hours", nil},
+		{FromMachine, "2h", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		// Into
+		{IntoMachine, "an hour and a half", io.ParseCliArgs([]string{""}), "5400", nil},
+		{IntoMachine, "P1DT2H", io.ParseCliArgs([]string{"--unit", "ms"}), "93600000", nil},
+		{IntoMachine, "1h30m", io.ParseCliArgs([]string{"--unit", "ns"}), "5400000000000", nil},
+		{IntoMachine, "7384", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+	}
+
+	duration := NewDuration()
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(duration, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%t` ; got `%t`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
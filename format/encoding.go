@@ -0,0 +1,69 @@
+package format
+
+import (
+	"fmt"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Encoding struct {
+	// scheme is the encoding to use, an empty string means guess it
+	scheme string
+}
+
+// EncodingOptions picks the encoding
+type EncodingOptions struct {
+	// Scheme is the encoding, ie: base64, hex. Empty means guess it when
+	// decoding
+	Scheme string `option:"scheme" choices:"hex,base32,base64,base64url,base58"`
+}
+
+func NewEncoding() Format {
+	return &Encoding{}
+}
+
+// NewEncodingScheme constructs the format for a single encoding, ie: so that
+// `human --into base64 hello` works
+func NewEncodingScheme(scheme string) Format {
+	return &Encoding{scheme: scheme}
+}
+
+func (e *Encoding) GetParsers() []parsers.Parser {
+	p, _ := parsers.NewEncoding(e.scheme)
+	return []parsers.Parser{p}
+}
+
+func (e *Encoding) Options() Options {
+	return &EncodingOptions{Scheme: e.scheme}
+}
+
+func (e *Encoding) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*EncodingOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	p, err := parsers.NewEncoding(o.Scheme)
+	if err != nil {
+		return "", err
+	}
+
+	if ok, _ := p.CanParseFromMachine(input); direction == FromMachine && ok {
+		return p.DoFromMachine(input)
+	}
+
+	if ok, _ := p.CanParseIntoMachine(input); direction == IntoMachine && ok {
+		return p.DoIntoMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
+
+// Detect trusts the decoding since the guessing is already strict. Anything
+// can be encoded so that's only done when asked for
+func (e *Encoding) Detect(direction Direction, input string) Confidence {
+	if direction == IntoMachine {
+		return NoConfidence
+	}
+	return HighConfidence
+}
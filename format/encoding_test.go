@@ -0,0 +1,43 @@
+package format
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestEncodingFormatRun(t *testing.T) {
+	tests := []struct {
+		format    Format
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		{NewEncoding(), FromMachine, "aGVsbG8gd29ybGQK", io.ParseCliArgs([]string{""}), "hello world", nil},
+		{NewEncoding(), FromMachine, "password", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{NewEncoding(), FromMachine, "aGk=", io.ParseCliArgs([]string{"--scheme", "base64"}), "hi", nil},
+		{NewEncoding(), IntoMachine, "hello world", io.ParseCliArgs([]string{""}), "aGVsbG8gd29ybGQ=", nil},
+		{NewEncoding(), IntoMachine, "hello world", io.ParseCliArgs([]string{"--scheme", "hex"}), "68656c6c6f20776f726c64", nil},
+		{NewEncoding(), IntoMachine, "hello world", io.ParseCliArgs([]string{"--scheme", "rot13"}), "", ErrBadOption},
+		{NewEncodingScheme("base64"), IntoMachine, "hello world", io.ParseCliArgs([]string{""}), "aGVsbG8gd29ybGQ=", nil},
+		{NewEncodingScheme("base58"), FromMachine, "StV1DL6CwTryKyV", io.ParseCliArgs([]string{""}), "hello world", nil},
+		{NewEncodingScheme("hex"), FromMachine, "00ff", io.ParseCliArgs([]string{""}), "00000000  00 ff                                             |..|", nil},
+	}
+
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(tt.format, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%t` ; got `%t`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
@@ -1,11 +1,106 @@
 package format
 
 import (
-	"github.com/andres-lowrie/human/io"
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/andres-lowrie/human/parsers"
 )
 
+var ErrUnknownDirection error = errors.New("Unknown direction, use from or into")
+var ErrWarnings error = errors.New("Converted with warnings")
+
+// Warnings are problems with the input that didn't stop it from being
+// converted (ie: numfmt --invalid=warn), formats give them back as the error
+// along with the output. Formats don't print anything, that's up to the caller.
+// Err is set when the conversion failed on top of that (ie: numfmt
+// --invalid=fail), it doesn't repeat the messages
+type Warnings struct {
+	Messages []string
+	Err      error
+}
+
+func (w *Warnings) Error() string {
+	if w.Err != nil {
+		return strings.Join(append(append([]string{}, w.Messages...), w.Err.Error()), "\n")
+	}
+	return strings.Join(w.Messages, "\n")
+}
+
+func (w *Warnings) Unwrap() error {
+	return w.Err
+}
+
+// Is makes `errors.Is(err, ErrWarnings)` true for any warnings
+func (w *Warnings) Is(target error) bool {
+	return target == ErrWarnings
+}
+
 type Format interface {
 	GetParsers() []parsers.Parser
-	Run(string, string, io.CliArgs) (string, error)
+	// Options gives back the format's options set to their defaults, it's a
+	// pointer to a struct of the format's own (ie: *SizeOptions)
+	Options() Options
+	// Run converts the input, the options have to be the ones given back by
+	// `Options` (or a struct of the same type)
+	Run(Direction, string, Options) (string, error)
+}
+
+// Direction is which way the input is converted
+type Direction int
+
+const (
+	// FromMachine converts what a machine wrote into something a human can
+	// read, ie: 1700000000 -> Tue, 14 Nov 2023 22:13:20 UTC
+	FromMachine Direction = iota
+	// IntoMachine converts what a human wrote into something a machine can
+	// read, ie: 2 hours -> 7200
+	IntoMachine
+)
+
+// ParseDirection reads the direction the way it's written on the command
+// line, ie: from or into
+func ParseDirection(s string) (Direction, error) {
+	switch s {
+	case "from":
+		return FromMachine, nil
+	case "into":
+		return IntoMachine, nil
+	}
+	return FromMachine, fmt.Errorf("%w: '%s'", ErrUnknownDirection, s)
+}
+
+func (d Direction) String() string {
+	if d == IntoMachine {
+		return "into"
+	}
+	return "from"
+}
+
+// Confidence is how sure a format is that the input was meant for it. It's
+// used to rank the outputs when the format isn't given since some inputs are
+// valid for more than one format, ie: 1777 is a number and also a file mode
+type Confidence int
+
+const (
+	NoConfidence Confidence = iota
+	LowConfidence
+	MediumConfidence
+	HighConfidence
+)
+
+// Detector is implemented by the formats that can tell how likely it is that
+// the input is meant for them
+type Detector interface {
+	Detect(direction Direction, input string) Confidence
+}
+
+// Detect gives back how sure the format is about the input, formats that
+// don't implement `Detector` are given a medium confidence
+func Detect(f Format, direction Direction, input string) Confidence {
+	if d, ok := f.(Detector); ok {
+		return d.Detect(direction, input)
+	}
+	return MediumConfidence
 }
@@ -0,0 +1,131 @@
+package format
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+)
+
+// run sets the format's options from the arguments the way the command line
+// does, then converts the input
+func run(f Format, direction Direction, input string, args io.CliArgs) (string, error) {
+	opts, err := ParseOptions(f, args)
+	if err != nil {
+		return "", err
+	}
+	return f.Run(direction, input, opts)
+}
+
+func TestParseOptions(t *testing.T) {
+	tests := []struct {
+		format Format
+		args   io.CliArgs
+		out    Options
+		err    error
+	}{
+		{NewSize(), io.ParseCliArgs([]string{""}), &SizeOptions{Units: "iec"}, nil},
+		{NewSize(), io.ParseCliArgs([]string{"--units=si", "--to=MB"}), &SizeOptions{Units: "si", To: "MB"}, nil},
+		{NewSize(), io.ParseCliArgs([]string{"--units=metric"}), nil, ErrBadOption},
+		{NewSize(), io.ParseCliArgs([]string{"--unit=si"}), nil, ErrUnknownOption},
+		{NewSize(), io.ParseCliArgs([]string{"-w"}), nil, ErrUnknownOption},
+		{NewNumber(), io.ParseCliArgs([]string{"-w"}), &NumberOptions{Words: true, Precision: 1}, nil},
+		{NewNumber(), io.ParseCliArgs([]string{"--compact"}), &NumberOptions{Compact: "short", Precision: 1}, nil},
+		{NewNumber(), io.ParseCliArgs([]string{"--precision=some"}), nil, ErrBadOption},
+		{NewRoman(), io.ParseCliArgs([]string{"--lenient"}), &RomanOptions{Lenient: true}, nil},
+		{NewRoman(), io.ParseCliArgs([]string{"--lenient=false"}), &RomanOptions{}, nil},
+		{NewRoman(), io.ParseCliArgs([]string{"--lenient=sure"}), nil, ErrBadOption},
+		{NewNumfmt(), io.ParseCliArgs([]string{"--header"}), &NumfmtOptions{Header: 1}, nil},
+		{NewCode(), io.ParseCliArgs([]string{"--kind=signal"}), &CodeOptions{Kind: "signal"}, nil},
+		{NewCode(), io.ParseCliArgs([]string{"--kind=zip"}), nil, ErrBadOption},
+		{NewEncoding(), io.ParseCliArgs([]string{"--scheme=base64url"}), &EncodingOptions{Scheme: "base64url"}, nil},
+		{NewEncoding(), io.ParseCliArgs([]string{"--scheme=rot13"}), nil, ErrBadOption},
+	}
+
+	for i, tt := range tests {
+		got, err := ParseOptions(tt.format, tt.args)
+		if tt.out != nil && !reflect.DeepEqual(got, tt.out) {
+			t.Errorf("Case %d: Given = `%v` ; want `%+v` ; got `%+v`", i, tt.args, tt.out, got)
+		}
+		if !errors.Is(err, tt.err) {
+			t.Errorf("Error Case %d: Given = `%v` ; want `%v` ; got `%v`", i, tt.args, tt.err, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		opts Options
+		err  error
+	}{
+		{&SizeOptions{Units: "si"}, nil},
+		{&SizeOptions{}, nil},
+		{&SizeOptions{Units: "metric"}, ErrBadOption},
+		{&TimeOptions{To: "unix"}, ErrBadOption},
+		{SizeOptions{}, ErrWrongOptions},
+	}
+
+	for i, tt := range tests {
+		if err := Validate(tt.opts); !errors.Is(err, tt.err) {
+			t.Errorf("Error Case %d: Given = `%+v` ; want `%v` ; got `%v`", i, tt.opts, tt.err, err)
+		}
+	}
+}
+
+func TestSwitches(t *testing.T) {
+	tests := []struct {
+		opts Options
+		out  []string
+	}{
+		{&RomanOptions{}, []string{"lenient", "vinculum"}},
+		{&SizeOptions{}, []string{"exact"}},
+		{&TimeOptions{}, nil},
+		{SizeOptions{}, nil},
+	}
+
+	for i, tt := range tests {
+		if got := Switches(tt.opts); !reflect.DeepEqual(got, tt.out) {
+			t.Errorf("Case %d: Given = `%+v` ; want `%v` ; got `%v`", i, tt.opts, tt.out, got)
+		}
+	}
+}
+
+func TestRunWrongOptions(t *testing.T) {
+	tests := []struct {
+		format Format
+		input  string
+	}{
+		{NewSize(), "1024"},
+		{NewNet(), "10.0.0.0/8"},
+		{NewCron(), "0 5 * * *"},
+	}
+
+	for i, tt := range tests {
+		if _, err := tt.format.Run(FromMachine, tt.input, &NumberOptions{}); !errors.Is(err, ErrWrongOptions) {
+			t.Errorf("Error Case %d: Given = `NumberOptions` ; want `%v` ; got `%v`", i, ErrWrongOptions, err)
+		}
+	}
+}
+
+func TestParseDirection(t *testing.T) {
+	tests := []struct {
+		input string
+		out   Direction
+		err   error
+	}{
+		{"from", FromMachine, nil},
+		{"into", IntoMachine, nil},
+		{"sideways", FromMachine, ErrUnknownDirection},
+	}
+
+	for i, tt := range tests {
+		got, err := ParseDirection(tt.input)
+		if got != tt.out {
+			t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.input, tt.out, got)
+		}
+		if !errors.Is(err, tt.err) {
+			t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.input, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Id struct {
+	// now is the clock used to reject Snowflakes from the future, it's here so
+	// that tests can stop time
+	now func() time.Time
+}
+
+// IdOptions picks how the time in the IDs is shown
+type IdOptions struct {
+	// Tz is the time zone the time is shown in, the local one by default
+	Tz string `option:"tz"`
+	// Epoch is the start of time for Snowflakes, ie: twitter, discord or a
+	// time in milliseconds
+	Epoch string `option:"epoch"`
+}
+
+func NewId() Format {
+	return &Id{now: time.Now}
+}
+
+func (i *Id) GetParsers() []parsers.Parser {
+	return []parsers.Parser{parsers.NewId(parsers.SnowflakeTwitterEpoch, nil, i.now)}
+}
+
+func (i *Id) Options() Options {
+	return &IdOptions{}
+}
+
+func (i *Id) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*IdOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	if direction != FromMachine {
+		return "", parsers.ErrUnparsable
+	}
+
+	loc := time.Local
+	if o.Tz != "" {
+		l, err := parsers.LoadTimezone(o.Tz)
+		if err != nil {
+			return "", err
+		}
+		loc = l
+	}
+
+	epoch := parsers.SnowflakeTwitterEpoch
+	if o.Epoch != "" {
+		parsed, err := parsers.ParseSnowflakeEpoch(o.Epoch)
+		if err != nil {
+			return "", err
+		}
+		epoch = parsed
+	}
+
+	p := parsers.NewId(epoch, loc, i.now)
+	if ok, _ := p.CanParseFromMachine(input); ok {
+		return p.DoFromMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
+
+// Detect is sure about anything that has the shape of an ID. Other than
+// Snowflakes, numbers are more likely to be numbers even when they have as
+// many digits as an ObjectID, ULID or KSUID, so those rank low
+func (i *Id) Detect(direction Direction, input string) Confidence {
+	if regexp.MustCompile(`^[0-9]+$`).MatchString(input) && !regexp.MustCompile(`^[1-9][0-9]{14,18}$`).MatchString(input) {
+		return LowConfidence
+	}
+	return HighConfidence
+}
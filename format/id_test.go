@@ -0,0 +1,62 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestIdFormatRun(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		{FromMachine, "017f22e2-79b0-7cc3-98c4-dc0c0c07398f", io.ParseCliArgs([]string{"--tz=UTC"}), "type:      UUID v7 (time ordered)\ntime:      Tue, 22 Feb 2022 19:22:22.000 UTC", nil},
+		{FromMachine, "175928847299117063", io.ParseCliArgs([]string{"--tz=UTC", "--epoch=discord"}), "type:      Snowflake\ntime:      Sat, 30 Apr 2016 11:18:25.796 UTC\nworker:    1\nprocess:   0\nsequence:  7", nil},
+		{FromMachine, "175928847299117063", io.ParseCliArgs([]string{"--epoch=myspace"}), "", parsers.ErrBadEpoch},
+		{FromMachine, "175928847299117063", io.ParseCliArgs([]string{"--tz=Nowhere/Special"}), "", parsers.ErrUnknownTimezone},
+		{FromMachine, "hello", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{IntoMachine, "017f22e2-79b0-7cc3-98c4-dc0c0c07398f", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+	}
+
+	id := &Id{now: func() time.Time { return time.Unix(1700000000, 0) }}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(id, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%v` ; got `%v`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestIdDetect(t *testing.T) {
+	tests := []struct {
+		input string
+		out   Confidence
+	}{
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAV", HighConfidence},
+		{"507f1f77bcf86cd799439011", HighConfidence},
+		{"1541815603606036480", HighConfidence},
+		// Only digits but as long as a KSUID, ULID or ObjectID
+		{"123456789012345678901234567", LowConfidence},
+		{"12345678901234567890123456", LowConfidence},
+		{"123456789012345678901234", LowConfidence},
+	}
+
+	id := NewId().(*Id)
+	for i, tt := range tests {
+		if got := id.Detect(FromMachine, tt.input); got != tt.out {
+			t.Errorf("Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.input, tt.out, got)
+		}
+	}
+}
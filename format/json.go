@@ -0,0 +1,185 @@
+package format
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+var ErrBadRule error = errors.New("Can't read rule, ie: *_bytes = size --units=si")
+
+// defaultJsonRules are used after the user's own rules, so they can be
+// overridden
+const defaultJsonRules = `
+*_bytes = size
+*_at = time
+*_seconds = duration
+*_ms = duration --unit=ms
+schedule = cron
+`
+
+// jsonRule says which format the values that match the pattern go through
+type jsonRule struct {
+	pattern string
+	format  string
+	opts    Options
+}
+
+// JsonOptions picks the rules and how the document is shown
+type JsonOptions struct {
+	// View is document (the default) to replace the values or annotated to
+	// show them next to the original ones
+	View string `option:"view" choices:"document,annotated"`
+	// Rules is a file with more rules, they go before the others
+	Rules string `option:"rules"`
+}
+
+type Json struct {
+	// lookup finds the formats the rules name
+	lookup func(string) Format
+	// rules is the file with the user's rules, it's read when it exists
+	rules string
+}
+
+func NewJson(lookup func(string) Format) Format {
+	rules := ""
+	if dir, err := os.UserConfigDir(); err == nil {
+		rules = filepath.Join(dir, "human", "rules")
+	}
+	return &Json{lookup: lookup, rules: rules}
+}
+
+func (j *Json) GetParsers() []parsers.Parser {
+	return []parsers.Parser{parsers.NewEmpty()}
+}
+
+func (j *Json) Options() Options {
+	return &JsonOptions{}
+}
+
+func (j *Json) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*JsonOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	if direction != FromMachine {
+		return "", parsers.ErrUnparsable
+	}
+
+	// The rules in the --rules file go first, then the ones in the config file
+	// and the defaults
+	var texts []string
+	if o.Rules != "" {
+		data, err := ioutil.ReadFile(o.Rules)
+		if err != nil {
+			return "", err
+		}
+		texts = append(texts, string(data))
+	}
+	if data, err := ioutil.ReadFile(j.rules); err == nil {
+		texts = append(texts, string(data))
+	}
+	texts = append(texts, defaultJsonRules)
+
+	var rules []jsonRule
+	for _, text := range texts {
+		r, err := j.parseRules(text)
+		if err != nil {
+			return "", err
+		}
+		rules = append(rules, r...)
+	}
+
+	// The format was asked for (the guessing goes through `Detect`) so why the
+	// document can't be read is worth telling, ie: a syntax error
+	doc, err := parsers.ParseDocument(input)
+	if err != nil {
+		return "", err
+	}
+
+	var failed error
+	doc.Rewrite(func(path, key, value string) (string, bool) {
+		for _, r := range rules {
+			matched, err := parsers.MatchDocumentPath(r.pattern, path, key)
+			if err != nil {
+				failed = err
+				return "", false
+			}
+			if !matched {
+				continue
+			}
+
+			// Values the format can't read are left as they are
+			out, err := j.lookup(r.format).Run(FromMachine, value, r.opts)
+			if err != nil || out == "" {
+				return "", false
+			}
+			return regexp.MustCompile(`\s*\n\s*`).ReplaceAllString(strings.TrimSpace(out), "; "), true
+		}
+		return "", false
+	})
+	if failed != nil {
+		return "", failed
+	}
+
+	if o.View == "annotated" {
+		return doc.Annotated(), nil
+	}
+	return doc.String(), nil
+}
+
+// parseRules reads the rules one per line:
+// 	<key pattern or JSONPath> = <format> [--option=value ...]
+// Empty lines and lines starting with # are skipped
+func (j *Json) parseRules(text string) ([]jsonRule, error) {
+	var rules []jsonRule
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		i := strings.Index(line, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("%w: '%s'", ErrBadRule, line)
+		}
+
+		fields := strings.Fields(line[i+1:])
+		if len(fields) == 0 || j.lookup(fields[0]) == nil {
+			return nil, fmt.Errorf("%w: '%s'", ErrBadRule, line)
+		}
+
+		// The options are checked as the rules are read so that a typo in one
+		// isn't mistaken for a value the format can't read
+		opts, err := ParseOptions(j.lookup(fields[0]), io.ParseCliArgs(fields[1:]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: '%s': %s", ErrBadRule, line, err)
+		}
+
+		rules = append(rules, jsonRule{
+			pattern: strings.TrimSpace(line[:i]),
+			format:  fields[0],
+			opts:    opts,
+		})
+	}
+	return rules, nil
+}
+
+// Detect is sure about JSON objects and lists, YAML has to be asked for
+// since just about anything is YAML
+func (j *Json) Detect(direction Direction, input string) Confidence {
+	if direction == FromMachine && regexp.MustCompile(`^\s*[{[]`).MatchString(input) {
+		return HighConfidence
+	}
+	return NoConfidence
+}
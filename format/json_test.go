@@ -0,0 +1,78 @@
+package format
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestJsonFormatRun(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "rules")
+	ioutil.WriteFile(config, []byte("# mine\n*_size = size --units=si\n"), 0644)
+	extra := filepath.Join(dir, "extra")
+	ioutil.WriteFile(extra, []byte("$.jobs[*].timeout = duration\n"), 0644)
+	bad := filepath.Join(dir, "bad")
+	ioutil.WriteFile(bad, []byte("*_x = nope\n"), 0644)
+
+	formats := map[string]Format{"size": NewSize(), "time": NewTime(), "duration": NewDuration(), "cron": NewCron()}
+	lookup := func(name string) Format { return formats[name] }
+
+	doc := `{"used_bytes": 2048, "disk_size": 2000, "schedule": "*/5 * * * *", "jobs": [{"timeout": 90, "read_bytes": "n/a"}]}`
+	yamlDoc := "used_bytes: 2048\njobs:\n  - timeout: 90"
+
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		{FromMachine, doc, io.ParseCliArgs([]string{""}), "{\n  \"used_bytes\": \"2.0Ki\",\n  \"disk_size\": \"2.0KB\",\n  \"schedule\": \"every 5 minutes\",\n  \"jobs\": [\n    {\n      \"timeout\": 90,\n      \"read_bytes\": \"n/a\"\n    }\n  ]\n}", nil},
+		{FromMachine, doc, io.ParseCliArgs([]string{"--rules=" + extra, "--view=annotated"}), "{\n  \"used_bytes\": 2048,         // 2.0Ki\n  \"disk_size\": 2000,          // 2.0KB\n  \"schedule\": \"*/5 * * * *\",  // every 5 minutes\n  \"jobs\": [\n    {\n      \"timeout\": 90,          // 1m 30s\n      \"read_bytes\": \"n/a\"\n    }\n  ]\n}", nil},
+		{FromMachine, yamlDoc, io.ParseCliArgs([]string{"--rules=" + extra}), "used_bytes: 2.0Ki\njobs:\n  - timeout: 1m 30s", nil},
+		{FromMachine, doc, io.ParseCliArgs([]string{"--rules=" + bad}), "", ErrBadRule},
+		{FromMachine, doc, io.ParseCliArgs([]string{"--view=table"}), "", ErrBadOption},
+		{FromMachine, "hello", io.ParseCliArgs([]string{""}), "", parsers.ErrNotADocument},
+		{FromMachine, `{"a": [`, io.ParseCliArgs([]string{""}), "", parsers.ErrNotADocument},
+		{IntoMachine, doc, io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+	}
+
+	j := &Json{lookup: lookup, rules: config}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(j, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%v` ; got `%v`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestJsonDetect(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		out       Confidence
+	}{
+		{FromMachine, `{"a": 1}`, HighConfidence},
+		{FromMachine, "  [1, 2]", HighConfidence},
+		{FromMachine, "a: 1", NoConfidence},
+		{IntoMachine, `{"a": 1}`, NoConfidence},
+	}
+
+	j := &Json{}
+	for i, tt := range tests {
+		if got := j.Detect(tt.direction, tt.input); got != tt.out {
+			t.Errorf("Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.input, tt.out, got)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package format
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Jwt struct {
+	// now is the clock used to tell if tokens expired, it's here so that tests
+	// can stop time
+	now func() time.Time
+}
+
+// JwtOptions picks how tokens are shown and checked
+type JwtOptions struct {
+	// Tz is the time zone the times are shown in, the local one by default
+	Tz string `option:"tz"`
+	// Secret checks the signature of HMAC tokens
+	Secret string `option:"secret"`
+	// Key is a file with PEM encoded keys or a JWKS to check the signature
+	Key string `option:"key"`
+}
+
+func NewJwt() Format {
+	return &Jwt{now: time.Now}
+}
+
+func (j *Jwt) GetParsers() []parsers.Parser {
+	p, _ := parsers.NewJwt("", nil, nil, j.now)
+	return []parsers.Parser{p}
+}
+
+func (j *Jwt) Options() Options {
+	return &JwtOptions{}
+}
+
+func (j *Jwt) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*JwtOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	if direction != FromMachine {
+		return "", parsers.ErrUnparsable
+	}
+
+	loc := time.Local
+	if o.Tz != "" {
+		l, err := parsers.LoadTimezone(o.Tz)
+		if err != nil {
+			return "", err
+		}
+		loc = l
+	}
+
+	// The key is a file with PEM encoded keys or a JWKS
+	var key []byte
+	if o.Key != "" {
+		data, err := ioutil.ReadFile(o.Key)
+		if err != nil {
+			return "", err
+		}
+		key = data
+	}
+
+	p, err := parsers.NewJwt(o.Secret, key, loc, j.now)
+	if err != nil {
+		return "", err
+	}
+
+	if ok, _ := p.CanParseFromMachine(input); ok {
+		return p.DoFromMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
+
+// Detect is sure about anything that decodes into a JOSE header
+func (j *Jwt) Detect(direction Direction, input string) Confidence {
+	return HighConfidence
+}
@@ -0,0 +1,105 @@
+package format
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Money struct {
+	// rates is the file with the user's exchange rates, it's read when it
+	// exists
+	rates string
+}
+
+// MoneyOptions picks the currency and how amounts are written
+type MoneyOptions struct {
+	// Currency is the currency of amounts that don't say, ie: USD
+	Currency string `option:"currency"`
+	// To is the currency amounts are converted into
+	To string `option:"to"`
+	// Locale is how amounts are written, ie: en, de, fr
+	Locale string `option:"locale"`
+	// Rates is a file with more exchange rates
+	Rates string `option:"rates"`
+}
+
+func NewMoney() Format {
+	rates := ""
+	if dir, err := os.UserConfigDir(); err == nil {
+		rates = filepath.Join(dir, "human", "rates")
+	}
+	return &Money{rates: rates}
+}
+
+func (m *Money) GetParsers() []parsers.Parser {
+	p, _ := parsers.NewMoney("", "", "", nil)
+	return []parsers.Parser{p}
+}
+
+func (m *Money) Options() Options {
+	return &MoneyOptions{}
+}
+
+func (m *Money) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*MoneyOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	// Rates given with --rates replace the ones in the config file for the
+	// same currencies
+	rates := parsers.NewExchangeRates()
+	if data, err := ioutil.ReadFile(m.rates); err == nil {
+		if err := rates.Read(string(data)); err != nil {
+			return "", err
+		}
+	}
+	if o.Rates != "" {
+		data, err := ioutil.ReadFile(o.Rates)
+		if err != nil {
+			return "", err
+		}
+		if err := rates.Read(string(data)); err != nil {
+			return "", err
+		}
+	}
+
+	p, err := parsers.NewMoney(o.Currency, o.To, o.Locale, rates)
+	if err != nil {
+		return "", err
+	}
+
+	// The checks only look at the shape of the input, so errors from the
+	// conversion itself (ie: a missing exchange rate) are given back as they are
+	if ok, _ := p.CanParseFromMachine(input); direction == FromMachine && ok {
+		return p.DoFromMachine(input)
+	}
+
+	if ok, _ := p.CanParseIntoMachine(input); direction == IntoMachine && ok {
+		return p.DoIntoMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
+
+// Detect is only sure about amounts with a currency in them, a bare number
+// of cents is far more likely to be just a number
+func (m *Money) Detect(direction Direction, input string) Confidence {
+	if direction == FromMachine {
+		return NoConfidence
+	}
+	switch {
+	case regexp.MustCompile(`[$€£¥₹₩₽₺₪฿₫₴]`).MatchString(input):
+		return HighConfidence
+	case regexp.MustCompile(`(?i)\b(dollars?|euros?|pounds?|yen|bucks)$`).MatchString(input):
+		return HighConfidence
+	case regexp.MustCompile(`^[A-Z]{3}\s|\s[A-Z]{3}$`).MatchString(input):
+		return MediumConfidence
+	}
+	return NoConfidence
+}
@@ -0,0 +1,76 @@
+package format
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestMoneyFormatRun(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "rates")
+	ioutil.WriteFile(config, []byte("EUR = 1.08 USD\n"), 0644)
+	extra := filepath.Join(dir, "extra")
+	ioutil.WriteFile(extra, []byte("EUR = 1.10 USD\n"), 0644)
+	bad := filepath.Join(dir, "bad")
+	ioutil.WriteFile(bad, []byte("EUR is 1.10 USD\n"), 0644)
+
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		{FromMachine, "123456", io.ParseCliArgs([]string{"--currency=EUR", "--locale=de"}), "1.234,56 €", nil},
+		{FromMachine, "100", io.ParseCliArgs([]string{"--currency=EUR", "--to=USD"}), "$1.08", nil},
+		{FromMachine, "100", io.ParseCliArgs([]string{"--currency=EUR", "--to=USD", "--rates=" + extra}), "$1.10", nil},
+		{FromMachine, "100", io.ParseCliArgs([]string{"--rates=" + bad}), "", parsers.ErrBadRate},
+		{FromMachine, "100", io.ParseCliArgs([]string{"--to=JPY"}), "", parsers.ErrNoRate},
+		{FromMachine, "100", io.ParseCliArgs([]string{"--currency=XYZ"}), "", parsers.ErrUnknownCurrency},
+		{FromMachine, "hello", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{IntoMachine, "$1.2k", io.ParseCliArgs([]string{""}), "120000", nil},
+		{IntoMachine, "two million dollars", io.ParseCliArgs([]string{""}), "200000000", nil},
+		{IntoMachine, "hello", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+	}
+
+	money := &Money{rates: config}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(money, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%v` ; got `%v`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestMoneyDetect(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		out       Confidence
+	}{
+		{FromMachine, "123456", NoConfidence},
+		{IntoMachine, "$1.2k", HighConfidence},
+		{IntoMachine, "1.234,56 €", HighConfidence},
+		{IntoMachine, "two million dollars", HighConfidence},
+		{IntoMachine, "100 EUR", MediumConfidence},
+		{IntoMachine, "1234", NoConfidence},
+	}
+
+	money := &Money{}
+	for i, tt := range tests {
+		if got := money.Detect(tt.direction, tt.input); got != tt.out {
+			t.Errorf("Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.input, tt.out, got)
+		}
+	}
+}
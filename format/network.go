@@ -0,0 +1,64 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Net struct{}
+
+// NetOptions is empty, addresses are always written the same way
+type NetOptions struct{}
+
+func NewNet() Format {
+	return &Net{}
+}
+
+func (n *Net) GetParsers() []parsers.Parser {
+	return []parsers.Parser{parsers.NewNetwork()}
+}
+
+func (n *Net) Options() Options {
+	return &NetOptions{}
+}
+
+func (n *Net) Run(direction Direction, input string, opts Options) (string, error) {
+	if _, ok := opts.(*NetOptions); !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	p := parsers.NewNetwork()
+
+	if ok, _ := p.CanParseFromMachine(input); direction == FromMachine && ok {
+		return p.DoFromMachine(input)
+	}
+
+	if ok, _ := p.CanParseIntoMachine(input); direction == IntoMachine && ok {
+		return p.DoIntoMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
+
+// Detect offers integers as IPv4 addresses, but with less confidence than
+// numbers. Integers that would start with 0. or that need IPv6 are only
+// converted when asked for since they're almost never addresses
+func (n *Net) Detect(direction Direction, input string) Confidence {
+	if direction == IntoMachine || !regexp.MustCompile(`^[0-9]+$`).MatchString(input) {
+		return HighConfidence
+	}
+
+	// Integers too big for 64 bits can only be IPv6 addresses
+	i, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return NoConfidence
+	}
+
+	if i >= 1<<24 && i < 1<<32 {
+		return LowConfidence
+	}
+	return NoConfidence
+}
@@ -0,0 +1,64 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestNetFormatRun(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		{FromMachine, "167772160", io.ParseCliArgs([]string{""}), "10.0.0.0", nil},
+		{FromMachine, "255.255.252.0", io.ParseCliArgs([]string{""}), "/22", nil},
+		{FromMachine, "192.168.1.1/32", io.ParseCliArgs([]string{""}), "network:   192.168.1.1/32\nnetmask:   255.255.255.255\nfirst:     192.168.1.1\nlast:      192.168.1.1\nhosts:     1", nil},
+		{FromMachine, "hello", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{IntoMachine, "10.0.0.0 netmask 255.255.255.0", io.ParseCliArgs([]string{""}), "10.0.0.0/24", nil},
+		{IntoMachine, "10.0.0.0", io.ParseCliArgs([]string{""}), "167772160", nil},
+		{IntoMachine, "167772160", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+	}
+
+	net := NewNet()
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(net, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%t` ; got `%t`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestNetDetect(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		out       Confidence
+	}{
+		{FromMachine, "167772160", LowConfidence},
+		{FromMachine, "1000", NoConfidence},
+		{FromMachine, "4294967296", NoConfidence},
+		{FromMachine, "123456789012345678901234567", NoConfidence},
+		{FromMachine, "10.0.0.0/22", HighConfidence},
+		{IntoMachine, "10.0.0.1", HighConfidence},
+	}
+
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Detect(NewNet(), tt.direction, tt.input); got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%d` ; got `%d`", i, tt.input, tt.out, got)
+			}
+		})
+	}
+}
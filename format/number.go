@@ -1,38 +1,84 @@
 package format
 
 import (
-	"github.com/andres-lowrie/human/io"
+	"fmt"
+	"strings"
+
 	"github.com/andres-lowrie/human/parsers"
 )
 
 type Number struct {
 }
 
+// NumberOptions picks how the number is written, grouped is the default
+type NumberOptions struct {
+	// Group writes the number with its digits grouped, it's the default
+	Group bool `flag:"g"`
+	// Words writes the number with the name of its greatest power, ie: 1200 ->
+	// 1.2 thousand
+	Words bool `flag:"w"`
+	// Compact abbreviates the number in the style, ie: 1200000 -> 1.2M
+	Compact string `option:"compact" choices:"short,si" empty:"short"`
+	// Precision is the maximum amount of decimals used by Compact
+	Precision int `option:"precision"`
+	// Exact keeps every digit for Words and Compact, so that the output reads
+	// back into the same number
+	Exact bool `option:"exact"`
+}
+
 func NewNumber() Format {
 	return &Number{}
 }
 
+func (n *Number) Options() Options {
+	return &NumberOptions{Precision: 1}
+}
+
 func (n *Number) GetParsers() []parsers.Parser {
-	return []parsers.Parser{parsers.NewNumberGroup(), parsers.NewNumberWord()}
+	return []parsers.Parser{parsers.NewNumberGroup(), parsers.NewNumberWord(), parsers.NewNumberCompact("short", 1)}
 }
 
-func (n *Number) Run(direction string, input string, args io.CliArgs) (string, error) {
+func (n *Number) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*NumberOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
 	// Figure out which of the parsers we're using, default to "groupping, -g"
 	var p parsers.Parser
 
 	p = parsers.NewNumberGroup()
 
-	if _, ok := args.Flags["w"]; ok {
-		p = parsers.NewNumberWord()
+	if o.Words {
+		word := parsers.NewNumberWord()
+		word.SetExact(o.Exact)
+		p = word
+	}
+
+	// The compact notation is an option instead of a flag since it takes the
+	// style as its value, ie: `--compact=si`. On its own it means "short"
+	if o.Compact != "" {
+		compact := parsers.NewNumberCompact(o.Compact, o.Precision)
+		compact.SetExact(o.Exact)
+		p = compact
 	}
 
-	if ok, _ := p.CanParseFromMachine(input); direction == "from" && ok {
+	if ok, _ := p.CanParseFromMachine(input); direction == FromMachine && ok {
 		return p.DoFromMachine(input)
 	}
 
-	if ok, _ := p.CanParseIntoMachine(input); direction == "into" && ok {
+	if ok, _ := p.CanParseIntoMachine(input); direction == IntoMachine && ok {
 		return p.DoIntoMachine(input)
 	}
 
 	return "", parsers.ErrUnparsable
 }
+
+// Detect is sure about numbers that are big enough to need grouping but not so
+// big that they're more likely to be IDs (ie: Snowflakes)
+func (n *Number) Detect(direction Direction, input string) Confidence {
+	if direction == FromMachine && len(input) >= 4 && len(input) < 15 && !strings.HasPrefix(input, "0") {
+		return HighConfidence
+	}
+	return MediumConfidence
+}
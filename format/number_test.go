@@ -9,30 +9,41 @@ import (
 
 func TestNumberFormatRun(t *testing.T) {
 	tests := []struct {
-		direction string
+		direction Direction
 		input     string
 		args      io.CliArgs
 		out       string
 		err       error
 	}{
 		// Should default to group
-		{"from", "10000", io.ParseCliArgs([]string{""}), "10,000", nil},
-		{"into", "100,000,000", io.ParseCliArgs([]string{""}), "100000000", nil},
+		{FromMachine, "10000", io.ParseCliArgs([]string{""}), "10,000", nil},
+		{IntoMachine, "100,000,000", io.ParseCliArgs([]string{""}), "100000000", nil},
 		// Should return error on bad input
-		{"from", "notanumber", io.ParseCliArgs([]string{"-w"}), "", parsers.ErrUnparsable},
+		{FromMachine, "notanumber", io.ParseCliArgs([]string{"-w"}), "", parsers.ErrUnparsable},
 		// Should return an error when nonsense input is detected
-		{"into", "xxxx", io.ParseCliArgs([]string{"-g"}), "", parsers.ErrUnparsable},
+		{IntoMachine, "xxxx", io.ParseCliArgs([]string{"-g"}), "", parsers.ErrUnparsable},
 		// Happy path
-		{"into", "250 thousand", io.ParseCliArgs([]string{"-w"}), "250000", nil},
-		{"from", "250000", io.ParseCliArgs([]string{"-w"}), "250 thousand", nil},
-		{"into", "250,000", io.ParseCliArgs([]string{"-g"}), "250000", nil},
-		{"from", "250000", io.ParseCliArgs([]string{"-g"}), "250,000", nil},
+		{IntoMachine, "250 thousand", io.ParseCliArgs([]string{"-w"}), "250000", nil},
+		{FromMachine, "250000", io.ParseCliArgs([]string{"-w"}), "250 thousand", nil},
+		{IntoMachine, "250,000", io.ParseCliArgs([]string{"-g"}), "250000", nil},
+		{FromMachine, "250000", io.ParseCliArgs([]string{"-g"}), "250,000", nil},
+		// Compact notation
+		{FromMachine, "1000000", io.ParseCliArgs([]string{"--compact"}), "1M", nil},
+		{FromMachine, "1234567", io.ParseCliArgs([]string{"--compact", "--precision=2"}), "1.23M", nil},
+		{FromMachine, "5000000000", io.ParseCliArgs([]string{"--compact=si"}), "5G", nil},
+		{IntoMachine, "1.2K", io.ParseCliArgs([]string{"--compact"}), "1200", nil},
+		{IntoMachine, "5G", io.ParseCliArgs([]string{"--compact=si"}), "5000000000", nil},
+		{FromMachine, "1234567", io.ParseCliArgs([]string{"--compact", "--exact"}), "1.234567M", nil},
+		{FromMachine, "1234567", io.ParseCliArgs([]string{"-w", "--exact"}), "1.234567 million", nil},
+		{IntoMachine, "1.234567 million", io.ParseCliArgs([]string{"-w"}), "1234567", nil},
+		// G isn't part of the short style
+		{IntoMachine, "5G", io.ParseCliArgs([]string{"--compact"}), "", parsers.ErrUnparsable},
 	}
 	number := NewNumber()
 	for i, tt := range tests {
 		tt := tt
 		t.Run(tt.input, func(t *testing.T) {
-			got, err := number.Run(tt.direction, tt.input, tt.args)
+			got, err := run(number, tt.direction, tt.input, tt.args)
 			if got != tt.out {
 				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
 			}
@@ -0,0 +1,74 @@
+package format
+
+import (
+	"fmt"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+// Numfmt mimics the GNU `numfmt` tool so that scripts can switch over to
+// human by only changing the name of the command
+type Numfmt struct{}
+
+// NumfmtOptions are the options of GNU numfmt that human understands
+type NumfmtOptions struct {
+	From    string `option:"from"`
+	To      string `option:"to"`
+	Suffix  string `option:"suffix"`
+	Round   string `option:"round"`
+	Field   string `option:"field"`
+	Invalid string `option:"invalid"`
+	Padding int    `option:"padding"`
+	// Header is the amount of lines to leave as they are, just like numfmt
+	// `--header` on its own means 1 line
+	Header int `option:"header" empty:"1"`
+}
+
+func NewNumfmt() Format {
+	return &Numfmt{}
+}
+
+func (n *Numfmt) Options() Options {
+	return &NumfmtOptions{}
+}
+
+func (n *Numfmt) GetParsers() []parsers.Parser {
+	p, _ := parsers.NewNumfmt(parsers.NumfmtOptions{From: "auto"})
+	return []parsers.Parser{p}
+}
+
+// Run ignores the direction since with numfmt that's given by the `--from` and
+// `--to` options
+func (n *Numfmt) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*NumfmtOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	p, err := parsers.NewNumfmt(parsers.NumfmtOptions{
+		From:    o.From,
+		To:      o.To,
+		Suffix:  o.Suffix,
+		Round:   o.Round,
+		Field:   o.Field,
+		Invalid: o.Invalid,
+		Padding: o.Padding,
+		Header:  o.Header,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	out, err := p.DoFromMachine(input)
+	if len(p.Warnings()) > 0 {
+		return out, &Warnings{Messages: p.Warnings(), Err: err}
+	}
+	return out, err
+}
+
+// Detect never offers numfmt when the format has to be guessed, without
+// options it gives back the input as it is (in both directions) which only
+// repeats it
+func (n *Numfmt) Detect(direction Direction, input string) Confidence {
+	return NoConfidence
+}
@@ -0,0 +1,61 @@
+package format
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestNumfmtFormatRun(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		// Should default to no scaling
+		{FromMachine, "1000", io.ParseCliArgs([]string{""}), "1000", nil},
+		{FromMachine, "1K", io.ParseCliArgs([]string{""}), "", parsers.ErrRejectingSuffix},
+		// Should ignore the direction
+		{IntoMachine, "1000", io.ParseCliArgs([]string{"--to=si"}), "1.0K", nil},
+		{FromMachine, "1000", io.ParseCliArgs([]string{"--to=si"}), "1.0K", nil},
+		{FromMachine, "1Mi", io.ParseCliArgs([]string{"--from=auto"}), "1048576", nil},
+		{FromMachine, "1000", io.ParseCliArgs([]string{"--to=si", "--suffix=B"}), "1.0KB", nil},
+		{FromMachine, "1000", io.ParseCliArgs([]string{"--padding=6"}), "  1000", nil},
+		{FromMachine, "1000", io.ParseCliArgs([]string{"--padding=-6"}), "1000  ", nil},
+		{FromMachine, "1999", io.ParseCliArgs([]string{"--to=si", "--round=down"}), "1.9K", nil},
+		{FromMachine, "size 1000\na 2000", io.ParseCliArgs([]string{"--to=si", "--field=2", "--header"}), "size 1000\na 2.0K", nil},
+		{FromMachine, "a\nb\n1000", io.ParseCliArgs([]string{"--to=si", "--header=2"}), "a\nb\n1.0K", nil},
+		{FromMachine, "x\n1000", io.ParseCliArgs([]string{"--to=si", "--invalid=ignore"}), "x\n1.0K", nil},
+		{FromMachine, "x\n1000", io.ParseCliArgs([]string{"--to=si", "--invalid=warn"}), "x\n1.0K", ErrWarnings},
+		// Should fail loudly on bad options
+		{FromMachine, "1000", io.ParseCliArgs([]string{"--to=auto"}), "", parsers.ErrBadNumfmtOption},
+		{FromMachine, "1000", io.ParseCliArgs([]string{"--padding=wide"}), "", ErrBadOption},
+		{FromMachine, "1000", io.ParseCliArgs([]string{"--header=top"}), "", ErrBadOption},
+	}
+
+	numfmt := NewNumfmt()
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(numfmt, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%t` ; got `%t`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestNumfmtDetect(t *testing.T) {
+	for _, d := range []Direction{FromMachine, IntoMachine} {
+		if got := Detect(NewNumfmt(), d, "1994"); got != NoConfidence {
+			t.Errorf("Case %s: Given = `1994` ; want `%v` ; got `%v`", d, NoConfidence, got)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/andres-lowrie/human/io"
+)
+
+var ErrUnknownOption error = errors.New("Unknown option")
+var ErrBadOption error = errors.New("Bad value for option")
+var ErrWrongOptions error = errors.New("Options are for another format")
+
+// Options are a format's settings, each format has a struct of its own and
+// gives back a pointer to it with the defaults from `Format.Options`. The
+// fields are set from the command line by their tags:
+// 	option:"name"   the field is set with `--name=value`
+// 	flag:"n"        the field (a bool) is set with `-n`
+// 	choices:"a,b"   the values the field can have, empty is always fine
+// 	empty:"value"   the value of `--name` on its own, booleans are true
+// Fields can be strings, booleans or integers
+type Options interface{}
+
+// ParseOptions gives back the format's options with the arguments set on top
+// of the defaults, options and flags the format doesn't have are an error
+func ParseOptions(f Format, args io.CliArgs) (Options, error) {
+	opts := f.Options()
+	if err := SetOptions(opts, args); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// SetOptions sets the options and flags in the arguments
+func SetOptions(opts Options, args io.CliArgs) error {
+	// Sorted so that the same arguments always fail the same way
+	names := make([]string, 0, len(args.Options))
+	for name := range args.Options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := SetOption(opts, name, args.Options[name]); err != nil {
+			return err
+		}
+	}
+
+	names = names[:0]
+	for name, set := range args.Flags {
+		if set {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := SetFlag(opts, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetOption sets the field for `--name=value`
+func SetOption(opts Options, name, value string) error {
+	field, tag, err := optionField(opts, "option", name)
+	if err != nil {
+		return err
+	}
+	if field == nil {
+		return fmt.Errorf("%w: '--%s'", ErrUnknownOption, name)
+	}
+
+	if value == "" {
+		value = tag.Get("empty")
+	}
+
+	switch field.Kind() {
+	case reflect.Bool:
+		if value == "" {
+			value = "true"
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%w: --%s '%s', use true or false", ErrBadOption, name, value)
+		}
+		field.SetBool(b)
+
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%w: --%s '%s', use a whole number", ErrBadOption, name, value)
+		}
+		field.SetInt(int64(n))
+
+	default:
+		if err := checkChoice(tag, name, value); err != nil {
+			return err
+		}
+		field.SetString(value)
+	}
+	return nil
+}
+
+// SetFlag sets the field for `-name`
+func SetFlag(opts Options, name string) error {
+	field, _, err := optionField(opts, "flag", name)
+	if err != nil {
+		return err
+	}
+	if field == nil || field.Kind() != reflect.Bool {
+		return fmt.Errorf("%w: '-%s'", ErrUnknownOption, name)
+	}
+	field.SetBool(true)
+	return nil
+}
+
+// Validate tells if the values of the options are ones they can have, it's
+// for options that weren't set through `SetOption` (ie: written in Go)
+func Validate(opts Options) error {
+	v := reflect.ValueOf(opts)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	t := v.Elem().Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("option")
+		if name == "" || t.Field(i).Type.Kind() != reflect.String {
+			continue
+		}
+		if err := checkChoice(t.Field(i).Tag, name, v.Elem().Field(i).String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Switches gives back the names of the options that are true or false, on the
+// command line they don't take the value that follows them (see
+// `io.ParseCliArgs`)
+func Switches(opts Options) []string {
+	v := reflect.ValueOf(opts)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+	t := v.Elem().Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("option")
+		if name != "" && t.Field(i).Type.Kind() == reflect.Bool {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// optionField finds the field whose `kind` tag (ie: option or flag) is
+// name, it's nil when there's none
+func optionField(opts Options, kind, name string) (*reflect.Value, reflect.StructTag, error) {
+	v := reflect.ValueOf(opts)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	t := v.Elem().Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get(kind) == name {
+			field := v.Elem().Field(i)
+			return &field, t.Field(i).Tag, nil
+		}
+	}
+	return nil, "", nil
+}
+
+func checkChoice(tag reflect.StructTag, name, value string) error {
+	choices := tag.Get("choices")
+	if choices == "" || value == "" {
+		return nil
+	}
+	for _, c := range strings.Split(choices, ",") {
+		if c == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: --%s '%s', use one of %s", ErrBadOption, name, value, strings.ReplaceAll(choices, ",", ", "))
+}
@@ -0,0 +1,79 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Perm struct{}
+
+// PermOptions picks how modes are shown
+type PermOptions struct {
+	// Words shows the English description, it's on by default
+	Words bool `option:"words"`
+	// Base is the mode chmod expressions are applied to, 0000 by default
+	Base string `option:"base"`
+}
+
+func NewPerm() Format {
+	return &Perm{}
+}
+
+func (p *Perm) GetParsers() []parsers.Parser {
+	return []parsers.Parser{parsers.NewPerm(true, 0)}
+}
+
+// Options shows the English description unless asked not to
+func (p *Perm) Options() Options {
+	return &PermOptions{Words: true}
+}
+
+func (p *Perm) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*PermOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	var base uint32
+	if o.Base != "" {
+		b, err := parsers.ParsePermBase(o.Base)
+		if err != nil {
+			return "", err
+		}
+		base = b
+	}
+
+	parser := parsers.NewPerm(o.Words, base)
+
+	if ok, _ := parser.CanParseFromMachine(input); direction == FromMachine && ok {
+		return parser.DoFromMachine(input)
+	}
+
+	if ok, _ := parser.CanParseIntoMachine(input); direction == IntoMachine && ok {
+		return parser.DoIntoMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
+
+// Detect is sure about modes with a leading zero (ie: 0755) and `ls -l`
+// notations, 4 digit numbers without the zero are most likely just numbers
+func (p *Perm) Detect(direction Direction, input string) Confidence {
+	if direction == IntoMachine {
+		if regexp.MustCompile(`^[-dlcbps]?[rwxsStT-]{9}$`).MatchString(input) {
+			return HighConfidence
+		}
+		return MediumConfidence
+	}
+
+	switch {
+	case regexp.MustCompile(`^0[0-7]{3,4}$`).MatchString(input):
+		return HighConfidence
+	case regexp.MustCompile(`^[0-7]{3}$`).MatchString(input):
+		return MediumConfidence
+	default:
+		return LowConfidence
+	}
+}
@@ -0,0 +1,68 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestPermFormatRun(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		{FromMachine, "0755", io.ParseCliArgs([]string{""}), "rwxr-xr-x\nowner can read/write/execute; group and others can read/execute", nil},
+		{FromMachine, "1777", io.ParseCliArgs([]string{"--words=false"}), "rwxrwxrwt", nil},
+		{FromMachine, "0855", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{IntoMachine, "drwxr-sr-x", io.ParseCliArgs([]string{""}), "2755", nil},
+		{IntoMachine, "u+x,go-w", io.ParseCliArgs([]string{"--base", "0666"}), "0744", nil},
+		{IntoMachine, "u+x", io.ParseCliArgs([]string{""}), "0100", nil},
+		{IntoMachine, "u+x", io.ParseCliArgs([]string{"--base", "999"}), "", parsers.ErrNotAPermission},
+		{IntoMachine, "0755", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+	}
+
+	perm := NewPerm()
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(perm, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%t` ; got `%t`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestPermDetect(t *testing.T) {
+	tests := []struct {
+		format    Format
+		direction Direction
+		input     string
+		out       Confidence
+	}{
+		{NewPerm(), FromMachine, "0755", HighConfidence},
+		{NewPerm(), FromMachine, "755", MediumConfidence},
+		{NewPerm(), FromMachine, "1777", LowConfidence},
+		{NewPerm(), IntoMachine, "drwxr-sr-x", HighConfidence},
+		{NewNumber(), FromMachine, "1777", HighConfidence},
+		{NewNumber(), FromMachine, "0755", MediumConfidence},
+		// Formats that don't know better are in the middle
+		{NewRoman(), FromMachine, "1777", MediumConfidence},
+	}
+
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Detect(tt.format, tt.direction, tt.input); got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%d` ; got `%d`", i, tt.input, tt.out, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package format
+
+import (
+	"fmt"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Rate struct{}
+
+// RateOptions picks the units rates are written in
+type RateOptions struct {
+	// Units is si for powers of 1000 (the default) or iec for powers of 1024
+	Units string `option:"units" choices:"si,iec"`
+	// To is the unit rates are converted into, ie: MB/s
+	To string `option:"to"`
+	// Size asks how long it'd take to transfer it at the input's rate
+	Size string `option:"size"`
+	// Exact doesn't round the rates, so that they read back into the same
+	// amount of bytes
+	Exact bool `option:"exact"`
+}
+
+func NewRate() Format {
+	return &Rate{}
+}
+
+func (r *Rate) GetParsers() []parsers.Parser {
+	return []parsers.Parser{parsers.NewRate("si", ""), parsers.NewRate("iec", "")}
+}
+
+func (r *Rate) Options() Options {
+	return &RateOptions{Units: "si"}
+}
+
+func (r *Rate) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*RateOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	p := parsers.NewRate(o.Units, o.To)
+	p.SetExact(o.Exact)
+
+	// Input that isn't a rate (or an amount of data) is just unparsable, but
+	// when it is one what went wrong is worth telling, ie: a bad --to or --size
+	plain := parsers.NewRate(o.Units, "")
+	if ok, _ := plain.CanParseFromMachine(input); direction == FromMachine && ok {
+		// When a size is given we're being asked how long it'd take to transfer
+		// it at the input's rate
+		if o.Size != "" {
+			return p.TransferTime(o.Size, input)
+		}
+		return p.DoFromMachine(input)
+	}
+
+	if ok, _ := p.CanParseIntoMachine(input); direction == IntoMachine && ok {
+		return p.DoIntoMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
@@ -0,0 +1,54 @@
+package format
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestRateFormatRun(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		// Should default to si
+		{FromMachine, "125000000", io.ParseCliArgs([]string{""}), "125 MB/s", nil},
+		{FromMachine, "125000000", io.ParseCliArgs([]string{"--units", "iec"}), "119.21 MiB/s", nil},
+		{FromMachine, "123456789", io.ParseCliArgs([]string{"--exact"}), "123.456789 MB/s", nil},
+		// Should convert into the given unit
+		{FromMachine, "1Gbps", io.ParseCliArgs([]string{"--to", "MB/s"}), "125 MB/s", nil},
+		{FromMachine, "100 MiB/s", io.ParseCliArgs([]string{"--to", "Mbps"}), "838.86 Mbps", nil},
+		{FromMachine, "8Gb", io.ParseCliArgs([]string{"--to", "GB"}), "1 GB", nil},
+		{FromMachine, "8Gb", io.ParseCliArgs([]string{"--to", "GB/s"}), "", parsers.ErrIncompatibleUnits},
+		{FromMachine, "1Gbps", io.ParseCliArgs([]string{"--to=furlongs"}), "", parsers.ErrUnknownSuffix},
+		// Should give back the transfer time when a size is given
+		{FromMachine, "100Mbps", io.ParseCliArgs([]string{"--size", "10GB"}), "13m 20s", nil},
+		{FromMachine, "100Mbps", io.ParseCliArgs([]string{"--size", "nonsense"}), "", parsers.ErrBadSize},
+		{FromMachine, "100Mbps", io.ParseCliArgs([]string{"--size=10XB"}), "", parsers.ErrUnknownSuffix},
+		{FromMachine, "xxxx", io.ParseCliArgs([]string{"--size=10GB"}), "", parsers.ErrUnparsable},
+		// Into
+		{IntoMachine, "1Gbps", io.ParseCliArgs([]string{""}), "125000000", nil},
+		{IntoMachine, "1 MiB/s", io.ParseCliArgs([]string{""}), "1048576", nil},
+		{IntoMachine, "1GB", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{FromMachine, "xxxx", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+	}
+
+	rate := NewRate()
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(rate, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%t` ; got `%t`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
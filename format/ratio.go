@@ -0,0 +1,69 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Ratio struct{}
+
+// RatioOptions picks how ratios are written
+type RatioOptions struct {
+	// To is the notation, ie: percent, odds, fraction, bps or nines
+	To string `option:"to"`
+	// Per is the period the downtime of nines is shown for, ie: day, month
+	Per string `option:"per"`
+}
+
+func NewRatio() Format {
+	return &Ratio{}
+}
+
+func (r *Ratio) GetParsers() []parsers.Parser {
+	p, _ := parsers.NewRatio("", "")
+	return []parsers.Parser{p}
+}
+
+func (r *Ratio) Options() Options {
+	return &RatioOptions{}
+}
+
+func (r *Ratio) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*RatioOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	p, err := parsers.NewRatio(o.To, o.Per)
+	if err != nil {
+		return "", err
+	}
+
+	if ok, _ := p.CanParseFromMachine(input); direction == FromMachine && ok {
+		return p.DoFromMachine(input)
+	}
+
+	if ok, _ := p.CanParseIntoMachine(input); direction == IntoMachine && ok {
+		return p.DoIntoMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
+
+// Detect is sure about decimals below 1 and about percentages and the like,
+// `3:4` and `3/4` could just as well be a time or a date
+func (r *Ratio) Detect(direction Direction, input string) Confidence {
+	switch {
+	case direction == FromMachine && regexp.MustCompile(`^0?\.[0-9]+$`).MatchString(input):
+		return HighConfidence
+	case direction == FromMachine:
+		return NoConfidence
+	case regexp.MustCompile(`(?i)(%|percent|bps|basis points?|\sout of\s|\sin\s)`).MatchString(input):
+		return HighConfidence
+	case regexp.MustCompile(`^[0-9]+\s*[:/]\s*[0-9]+$`).MatchString(input):
+		return LowConfidence
+	}
+	return NoConfidence
+}
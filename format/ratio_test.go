@@ -0,0 +1,65 @@
+package format
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestRatioFormatRun(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		{FromMachine, "0.3333", io.ParseCliArgs([]string{"--to=odds"}), "about 1 in 3", nil},
+		{FromMachine, "99.95%", io.ParseCliArgs([]string{"--to=nines"}), "21m 54s of downtime per month", nil},
+		{FromMachine, "99.9", io.ParseCliArgs([]string{"--to=nines", "--per=week"}), "10m 5s of downtime per week", nil},
+		{FromMachine, "0.5", io.ParseCliArgs([]string{"--to=decimal"}), "", parsers.ErrUnknownRatioNotation},
+		{FromMachine, "0.5", io.ParseCliArgs([]string{"--per=fortnight"}), "", parsers.ErrUnknownPeriod},
+		{FromMachine, "hello", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{IntoMachine, "3 out of 4", io.ParseCliArgs([]string{""}), "0.75", nil},
+		{IntoMachine, "hello", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+	}
+
+	ratio := NewRatio()
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(ratio, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%v` ; got `%v`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestRatioDetect(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		out       Confidence
+	}{
+		{FromMachine, "0.3333", HighConfidence},
+		{FromMachine, ".5", HighConfidence},
+		{FromMachine, "1777", NoConfidence},
+		{IntoMachine, "75%", HighConfidence},
+		{IntoMachine, "3 out of 4", HighConfidence},
+		{IntoMachine, "3:4", LowConfidence},
+		{IntoMachine, "0.75", NoConfidence},
+	}
+
+	ratio := &Ratio{}
+	for i, tt := range tests {
+		if got := ratio.Detect(tt.direction, tt.input); got != tt.out {
+			t.Errorf("Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.input, tt.out, got)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package format
+
+import (
+	"fmt"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Roman struct{}
+
+// RomanOptions picks which numerals are understood
+type RomanOptions struct {
+	// Lenient reads numerals that aren't in their canonical form, ie: IIII
+	Lenient bool `option:"lenient"`
+	// Vinculum reads and writes numbers above 3999 with a bar over the
+	// thousands
+	Vinculum bool `option:"vinculum"`
+}
+
+func NewRoman() Format {
+	return &Roman{}
+}
+
+func (r *Roman) GetParsers() []parsers.Parser {
+	return []parsers.Parser{parsers.NewRoman(false, false)}
+}
+
+func (r *Roman) Options() Options {
+	return &RomanOptions{}
+}
+
+func (r *Roman) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*RomanOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	p := parsers.NewRoman(o.Lenient, o.Vinculum)
+
+	if ok, _ := p.CanParseFromMachine(input); direction == FromMachine && ok {
+		return p.DoFromMachine(input)
+	}
+
+	if ok, _ := p.CanParseIntoMachine(input); direction == IntoMachine && ok {
+		return p.DoIntoMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
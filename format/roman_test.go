@@ -0,0 +1,46 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestRomanFormatRun(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		// Happy path
+		{FromMachine, "1994", io.ParseCliArgs([]string{""}), "MCMXCIV", nil},
+		{IntoMachine, "MCMXCIV", io.ParseCliArgs([]string{""}), "1994", nil},
+		// Should only accept canonical numerals unless told otherwise
+		{IntoMachine, "IIII", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{IntoMachine, "IIII", io.ParseCliArgs([]string{"--lenient"}), "4", nil},
+		{IntoMachine, "IIII", io.ParseCliArgs([]string{"--lenient=false"}), "", parsers.ErrUnparsable},
+		// Should only go past 3999 with the vinculum
+		{FromMachine, "4000", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{FromMachine, "4000", io.ParseCliArgs([]string{"--vinculum"}), "I̅V̅", nil},
+		{IntoMachine, "V̅", io.ParseCliArgs([]string{"--vinculum"}), "5000", nil},
+		// Should fail if input is unparsable
+		{FromMachine, "xxxx", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+	}
+
+	roman := NewRoman()
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(roman, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%t` ; got `%t`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
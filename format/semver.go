@@ -0,0 +1,69 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Semver struct{}
+
+// SemverOptions picks the syntax of constraints
+type SemverOptions struct {
+	// Syntax is the package manager the constraints are written for, ie: npm
+	// or cargo
+	Syntax string `option:"syntax"`
+}
+
+func NewSemver() Format {
+	return &Semver{}
+}
+
+func (s *Semver) GetParsers() []parsers.Parser {
+	p, _ := parsers.NewSemver("")
+	return []parsers.Parser{p}
+}
+
+func (s *Semver) Options() Options {
+	return &SemverOptions{}
+}
+
+func (s *Semver) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*SemverOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	p, err := parsers.NewSemver(o.Syntax)
+	if err != nil {
+		return "", err
+	}
+
+	if ok, _ := p.CanParseFromMachine(input); direction == FromMachine && ok {
+		return p.DoFromMachine(input)
+	}
+
+	if ok, err := p.CanParseIntoMachine(input); direction == IntoMachine && ok {
+		return p.DoIntoMachine(input)
+	} else if direction == IntoMachine && err == parsers.ErrNotExpressible {
+		return "", err
+	}
+
+	return "", parsers.ErrUnparsable
+}
+
+// Detect is sure about constraints and versions with 3 parts, versions with
+// fewer parts are just as likely to be numbers and ones with more to be IP
+// addresses
+func (s *Semver) Detect(direction Direction, input string) Confidence {
+	switch {
+	case direction == IntoMachine:
+		return HighConfidence
+	case regexp.MustCompile(`^[0-9]+(\.[0-9]+){0,1}$`).MatchString(input):
+		return NoConfidence
+	case regexp.MustCompile(`^[0-9]+(\.[0-9]+){3,}$`).MatchString(input):
+		return LowConfidence
+	}
+	return HighConfidence
+}
@@ -0,0 +1,41 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestSemverFormatRun(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		{FromMachine, "^0.2.3", io.ParseCliArgs([]string{""}), ">=0.2.3 and <0.3.0", nil},
+		{FromMachine, "1.2.3", io.ParseCliArgs([]string{"--syntax=cargo"}), ">=1.2.3 and <2.0.0", nil},
+		{FromMachine, "1.2.3", io.ParseCliArgs([]string{"--syntax=maven"}), "", parsers.ErrUnknownSyntax},
+		{FromMachine, "hello", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{IntoMachine, "any 1.x after 1.4", io.ParseCliArgs([]string{""}), "^1.4.0", nil},
+		{IntoMachine, "any 1.x after 1.4", io.ParseCliArgs([]string{"--syntax=pep440"}), "~=1.4", nil},
+		{IntoMachine, "1.x or 3.x", io.ParseCliArgs([]string{"--syntax=cargo"}), "", parsers.ErrNotExpressible},
+		{IntoMachine, "^1.4.0", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+	}
+
+	semver := NewSemver()
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(semver, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%v` ; got `%v`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
@@ -1,16 +1,31 @@
 package format
 
 import (
-	"github.com/andres-lowrie/human/io"
+	"fmt"
+
 	"github.com/andres-lowrie/human/parsers"
 )
 
 type Size struct{}
 
+// SizeOptions picks the units sizes are written in
+type SizeOptions struct {
+	// Units is iec for powers of 1024 (the default) or si for powers of 1000
+	Units string `option:"units" choices:"iec,si"`
+	// To is the unit every size is written in, ie: MiB, GB, K
+	To string `option:"to"`
+	// Exact adds the bytes lost to rounding, ie: 1.5Gi + 12288B
+	Exact bool `option:"exact"`
+}
+
 func NewSize() Format {
 	return &Size{}
 }
 
+func (s *Size) Options() Options {
+	return &SizeOptions{Units: "iec"}
+}
+
 func (s *Size) GetParsers() []parsers.Parser {
 	// Given that when this method gets called we don't know what units the user
 	// is wanting to use, we give back all options so that the parsing check can
@@ -18,24 +33,42 @@ func (s *Size) GetParsers() []parsers.Parser {
 	return []parsers.Parser{parsers.NewSize("iec"), parsers.NewSize("si")}
 }
 
-func (s *Size) Run(direction, input string, args io.CliArgs) (string, error) {
+func (s *Size) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*SizeOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
 	// We know from the implementation that `iec` is the default so we'll only
 	// check for others and default to `iec` if we find nothing
 	var p parsers.Parser
-	units := args.Options["units"]
 
-	switch units {
+	var sz *parsers.Size
+	switch o.Units {
 	case "si":
-		p = parsers.NewSize("si")
+		sz = parsers.NewSize("si")
 	default:
-		p = parsers.NewSize("iec")
+		sz = parsers.NewSize("iec")
+	}
+
+	// Forcing the unit is what allows a list of sizes to all be written the
+	// same way, eg: when they're the rows of a table
+	if err := sz.SetTarget(o.To); err != nil {
+		return "", err
 	}
+	sz.SetExact(o.Exact)
+	p = sz
 
-	if ok, _ := p.CanParseFromMachine(input); direction == "from" && ok {
+	if ok, _ := p.CanParseFromMachine(input); direction == FromMachine && ok {
 		return p.DoFromMachine(input)
 	}
 
-	if ok, _ := p.CanParseIntoMachine(input); direction == "into" && ok {
+	// Sizes with a unit that can't be read (ie: 5 mb) are worth telling about,
+	// anything that isn't a size at all is just unparsable
+	if direction == IntoMachine {
+		if ok, err := p.CanParseIntoMachine(input); !ok {
+			return "", err
+		}
 		return p.DoIntoMachine(input)
 	}
 
@@ -9,30 +9,44 @@ import (
 
 func TestSizeFormatRun(t *testing.T) {
 	tests := []struct {
-		direction string
+		direction Direction
 		input     string
 		args      io.CliArgs
 		out       string
 		err       error
 	}{
 		// Should default to iec
-		{"from", "1024", io.ParseCliArgs([]string{""}), "1.0Ki", nil},
-		{"into", "1Mi", io.ParseCliArgs([]string{""}), "1048576", nil},
+		{FromMachine, "1024", io.ParseCliArgs([]string{""}), "1.0Ki", nil},
+		{IntoMachine, "1Mi", io.ParseCliArgs([]string{""}), "1048576", nil},
 		// Should accept a `units` option
-		{"from", "1024", io.ParseCliArgs([]string{"--units", "iec"}), "1.0Ki", nil},
-		{"from", "1000", io.ParseCliArgs([]string{"--units", "si"}), "1.0Kb", nil},
+		{FromMachine, "1024", io.ParseCliArgs([]string{"--units", "iec"}), "1.0Ki", nil},
+		{FromMachine, "1000", io.ParseCliArgs([]string{"--units", "si"}), "1.0KB", nil},
 		// Should fail if input is unparsable
-		{"from", "xxxx", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{FromMachine, "xxxx", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
 		// Happy Path
-		{"from", "2097152", io.ParseCliArgs([]string{"--units", "iec"}), "2.0Mi", nil},
-		{"into", "1G", io.ParseCliArgs([]string{"--units", "si"}), "1000000000", nil},
+		{FromMachine, "2097152", io.ParseCliArgs([]string{"--units", "iec"}), "2.0Mi", nil},
+		{IntoMachine, "1G", io.ParseCliArgs([]string{"--units", "si"}), "1000000000", nil},
+		// Should accept a target unit
+		{FromMachine, "1023999", io.ParseCliArgs([]string{"--to", "auto"}), "1.0Mi", nil},
+		{FromMachine, "1073741824", io.ParseCliArgs([]string{"--to", "MiB"}), "1024.0Mi", nil},
+		{FromMachine, "1000000", io.ParseCliArgs([]string{"--to", "KB"}), "1000.0KB", nil},
+		{FromMachine, "1000000", io.ParseCliArgs([]string{"--to", "furlongs"}), "", parsers.ErrUnknownSuffix},
+		{IntoMachine, "1,024 KiB", io.ParseCliArgs([]string{""}), "1048576", nil},
+		{IntoMachine, "1.5 gigabytes", io.ParseCliArgs([]string{""}), "1500000000", nil},
+		{FromMachine, "1610625024", io.ParseCliArgs([]string{"--exact"}), "1.5Gi + 12288B", nil},
+		{IntoMachine, "1.5Gi + 12288B", io.ParseCliArgs([]string{""}), "1610625024", nil},
+		// Should fail on ambiguous units
+		{IntoMachine, "5 mb", io.ParseCliArgs([]string{"--units", "si"}), "", parsers.ErrAmbiguousUnit},
+		{IntoMachine, "1.5 gigs", io.ParseCliArgs([]string{""}), "", parsers.ErrUnknownSuffix},
+		{IntoMachine, "5 GB", io.ParseCliArgs([]string{""}), "5000000000", nil},
+		{IntoMachine, "hello", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
 	}
 
 	size := NewSize()
 	for i, tt := range tests {
 		tt := tt
 		t.Run(tt.input, func(t *testing.T) {
-			got, err := size.Run(tt.direction, tt.input, tt.args)
+			got, err := run(size, tt.direction, tt.input, tt.args)
 			if got != tt.out {
 				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
 			}
@@ -0,0 +1,70 @@
+package format
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Time struct {
+	// now is the clock used for relative times, it's here so that tests can
+	// stop time
+	now func() time.Time
+}
+
+// TimeOptions picks how times are written and read
+type TimeOptions struct {
+	// Tz is the time zone times are shown in, the local one by default
+	Tz string `option:"tz"`
+	// Style is absolute (the default), relative to now or calendar
+	Style string `option:"style" choices:"absolute,relative,calendar"`
+	// Unit is the unit of the epoch, it's guessed from its size by default
+	Unit string `option:"unit" choices:"s,ms,us,µs,ns"`
+	// To is what times are read into, epoch (the default) or rfc3339
+	To string `option:"to" choices:"epoch,rfc3339"`
+}
+
+func NewTime() Format {
+	return &Time{now: time.Now}
+}
+
+func (t *Time) GetParsers() []parsers.Parser {
+	return []parsers.Parser{
+		parsers.NewTimestamp("absolute", "", "epoch", nil, t.now),
+		parsers.NewTimestamp("relative", "", "epoch", nil, t.now),
+		parsers.NewTimestamp("calendar", "", "epoch", nil, t.now),
+	}
+}
+
+func (t *Time) Options() Options {
+	return &TimeOptions{}
+}
+
+func (t *Time) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*TimeOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	loc := time.Local
+	if o.Tz != "" {
+		l, err := parsers.LoadTimezone(o.Tz)
+		if err != nil {
+			return "", err
+		}
+		loc = l
+	}
+
+	p := parsers.NewTimestamp(o.Style, o.Unit, o.To, loc, t.now)
+
+	if ok, _ := p.CanParseFromMachine(input); direction == FromMachine && ok {
+		return p.DoFromMachine(input)
+	}
+
+	if ok, _ := p.CanParseIntoMachine(input); direction == IntoMachine && ok {
+		return p.DoIntoMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
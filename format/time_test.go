@@ -0,0 +1,45 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestTimeFormatRun(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		{FromMachine, "1700000000", io.ParseCliArgs([]string{"--tz", "UTC"}), "Tue, 14 Nov 2023 22:13:20 UTC", nil},
+		{FromMachine, "1700000000000", io.ParseCliArgs([]string{"--tz", "UTC"}), "Tue, 14 Nov 2023 22:13:20 UTC", nil},
+		{FromMachine, "1699740800", io.ParseCliArgs([]string{"--style", "relative"}), "3 days ago", nil},
+		{FromMachine, "1699833600", io.ParseCliArgs([]string{"--style", "calendar", "--tz", "UTC"}), "yesterday at 12:00 AM", nil},
+		{FromMachine, "1700000000", io.ParseCliArgs([]string{"--tz", "Nowhere/Special"}), "", parsers.ErrUnknownTimezone},
+		{FromMachine, "next tuesday", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		// Into
+		{IntoMachine, "2 weeks ago", io.ParseCliArgs([]string{""}), "1698790400", nil},
+		{IntoMachine, "2 weeks ago", io.ParseCliArgs([]string{"--unit", "ms"}), "1698790400000", nil},
+		{IntoMachine, "next tuesday at 5pm", io.ParseCliArgs([]string{"--to", "rfc3339", "--tz", "UTC"}), "2023-11-21T17:00:00Z", nil},
+		{IntoMachine, "1700000000", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+	}
+
+	format := &Time{now: func() time.Time { return time.Unix(1700000000, 0) }}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(format, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%t` ; got `%t`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+}
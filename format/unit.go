@@ -0,0 +1,100 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/andres-lowrie/human/parsers"
+)
+
+type Unit struct {
+	// config is the file with the user's own units, it's read when it exists
+	config string
+}
+
+// UnitOptions picks the unit quantities are converted into
+type UnitOptions struct {
+	// To is the unit to convert into, ie: km/h
+	To string `option:"to"`
+	// UnitsFile is a file with more units, or new definitions for the builtin
+	// ones
+	UnitsFile string `option:"units-file"`
+}
+
+func NewUnit() Format {
+	config := ""
+	if dir, err := os.UserConfigDir(); err == nil {
+		config = filepath.Join(dir, "human", "units")
+	}
+	return &Unit{config: config}
+}
+
+func (u *Unit) GetParsers() []parsers.Parser {
+	table, _ := parsers.NewUnitTable()
+	p, _ := parsers.NewUnit("", table)
+	return []parsers.Parser{p}
+}
+
+func (u *Unit) Options() Options {
+	return &UnitOptions{}
+}
+
+func (u *Unit) Run(direction Direction, input string, opts Options) (string, error) {
+	o, ok := opts.(*UnitOptions)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongOptions, opts)
+	}
+
+	// The user's units are added to the builtin ones, first the ones in the
+	// config file and then the ones asked for
+	var extra []string
+	if data, err := ioutil.ReadFile(u.config); err == nil {
+		extra = append(extra, string(data))
+	}
+	if o.UnitsFile != "" {
+		data, err := ioutil.ReadFile(o.UnitsFile)
+		if err != nil {
+			return "", err
+		}
+		extra = append(extra, string(data))
+	}
+
+	table, err := parsers.NewUnitTable(extra...)
+	if err != nil {
+		return "", err
+	}
+
+	p, err := parsers.NewUnit(o.To, table)
+	if err != nil {
+		return "", err
+	}
+
+	// Converting between things that can't be converted is worth reporting
+	var incompatible *parsers.IncompatibleUnitsError
+	can, err := p.CanParseFromMachine(input)
+	if errors.As(err, &incompatible) {
+		return "", err
+	}
+
+	if direction == FromMachine && can {
+		return p.DoFromMachine(input)
+	}
+
+	if direction == IntoMachine && can {
+		return p.DoIntoMachine(input)
+	}
+
+	return "", parsers.ErrUnparsable
+}
+
+// Detect only offers conversions, the number on its own (the machine side)
+// is only written when asked for
+func (u *Unit) Detect(direction Direction, input string) Confidence {
+	if direction == IntoMachine {
+		return NoConfidence
+	}
+	return MediumConfidence
+}
@@ -0,0 +1,56 @@
+package format
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestUnitFormatRun(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "units")
+	ioutil.WriteFile(config, []byte("furlong, furlongs = 201.168 m -> mi\n"), 0644)
+	extra := filepath.Join(dir, "extra")
+	ioutil.WriteFile(extra, []byte("league, leagues = 3 mi\n"), 0644)
+
+	tests := []struct {
+		direction Direction
+		input     string
+		args      io.CliArgs
+		out       string
+		err       error
+	}{
+		{FromMachine, "5mi", io.ParseCliArgs([]string{""}), "8.04672 km", nil},
+		{FromMachine, "5mi", io.ParseCliArgs([]string{"--to=m"}), "8046.72 m", nil},
+		{IntoMachine, "5mi", io.ParseCliArgs([]string{"--to=m"}), "8046.72", nil},
+		{FromMachine, "8 furlongs", io.ParseCliArgs([]string{""}), "1 mi", nil},
+		{FromMachine, "1 league", io.ParseCliArgs([]string{"--units-file=" + extra, "--to=furlongs"}), "24 furlongs", nil},
+		{FromMachine, "1 league", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{FromMachine, "hello", io.ParseCliArgs([]string{""}), "", parsers.ErrUnparsable},
+		{FromMachine, "5mi", io.ParseCliArgs([]string{"--to=nope"}), "", parsers.ErrUnknownUnit},
+	}
+
+	unit := &Unit{config: config}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := run(unit, tt.direction, tt.input, tt.args)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` Args = `%v+`; want `%s` ; got `%s`", i, tt.input, tt.args, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` Args = `%v+`; want `%v` ; got `%v`", i, tt.input, tt.args, tt.err, err)
+			}
+		})
+	}
+
+	_, err := run(unit, FromMachine, "5mi", io.ParseCliArgs([]string{"--to=kg"}))
+	var incompatible *parsers.IncompatibleUnitsError
+	if !errors.As(err, &incompatible) {
+		t.Errorf("want IncompatibleUnitsError ; got `%v`", err)
+	}
+}
@@ -0,0 +1,127 @@
+package human
+
+import (
+	"sort"
+
+	"github.com/andres-lowrie/human/format"
+)
+
+// handlers are the formats that are tried when none is asked for
+var handlers = map[string]format.Format{
+	"number":   format.NewNumber(),
+	"size":     format.NewSize(),
+	"roman":    format.NewRoman(),
+	"base":     format.NewBase(),
+	"rate":     format.NewRate(),
+	"numfmt":   format.NewNumfmt(),
+	"duration": format.NewDuration(),
+	"time":     format.NewTime(),
+	"perm":     format.NewPerm(),
+	"encoding": format.NewEncoding(),
+	"jwt":      format.NewJwt(),
+	"net":      format.NewNet(),
+	"id":       format.NewId(),
+	"code":     format.NewCode(),
+	"semver":   format.NewSemver(),
+	"color":    format.NewColor(),
+	"unit":     format.NewUnit(),
+	"ratio":    format.NewRatio(),
+	"money":    format.NewMoney(),
+	"bits":     format.NewBits(),
+	"cron":     format.NewCron(),
+}
+
+// Aliases can be asked for by name but aren't run when the format has to be
+// guessed, otherwise they'd just repeat what the format they alias does
+var aliases = map[string]format.Format{
+	"base64":    format.NewEncodingScheme("base64"),
+	"base64url": format.NewEncodingScheme("base64url"),
+	"base32":    format.NewEncodingScheme("base32"),
+	"base58":    format.NewEncodingScheme("base58"),
+	"hex":       format.NewEncodingScheme("hex"),
+}
+
+// priority is the order the formats are tried in, when they're as sure about
+// the input the one that comes first wins. The most common readings go first,
+// ie: 123456789 is more likely a size than a rate
+var priority = []string{
+	"number",
+	"size",
+	"time",
+	"duration",
+	"rate",
+	"base",
+	"roman",
+	"perm",
+	"numfmt",
+	"json",
+	"encoding",
+	"jwt",
+	"id",
+	"net",
+	"code",
+	"semver",
+	"color",
+	"unit",
+	"ratio",
+	"money",
+	"bits",
+	"cron",
+}
+
+func init() {
+	// The json format runs the other formats on the values of the document, it
+	// reads YAML too
+	handlers["json"] = format.NewJson(Lookup)
+	aliases["yaml"] = handlers["json"]
+}
+
+// Lookup finds a format (or an alias of one) by name, it's nil when there's
+// no such format
+func Lookup(name string) format.Format {
+	if f, ok := handlers[name]; ok {
+		return f
+	}
+	return aliases[name]
+}
+
+// Formats lists the names of the formats that are tried when none is asked
+// for in the order they're tried, aliases aren't in it
+func Formats() []string {
+	names := make([]string, 0, len(handlers))
+	names = append(names, priority...)
+
+	// Formats without a place in the priority go last
+	var rest []string
+	for name := range handlers {
+		if !contains(priority, name) {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// Switches lists the options of all the formats that are true or false, on the
+// command line they never take the value that follows them
+func Switches() []string {
+	var names []string
+	for _, name := range Formats() {
+		for _, s := range format.Switches(Lookup(name).Options()) {
+			if !contains(names, s) {
+				names = append(names, s)
+			}
+		}
+	}
+	return names
+}
+
+// contains determines if the string is in the list
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,264 @@
+// Package human is the way to use human's conversions from Go, it's what the
+// command line application is built on.
+//
+// 	res, err := human.Humanize(ctx, "123456789", human.WithFormat("size"), human.WithUnits("si"))
+// 	// res.Value == "123.5MB"
+//
+// 	res, err = human.Parse(ctx, "2 hours", human.WithFormat("duration"))
+// 	// res.Value == "7200"
+//
+// Each format has its options in a struct of its own (ie: format.SizeOptions),
+// they can be given with `WithOptions` or one by one by their command line
+// names with `WithOption`. Options the format doesn't have are an error
+//
+// When no format is given every format is tried and the one most sure about
+// the input wins, `All` gives back every interpretation instead
+package human
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/andres-lowrie/human/format"
+	"github.com/andres-lowrie/human/io"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+// ErrUnknownFormat is returned when the format asked for doesn't exist
+var ErrUnknownFormat error = errors.New("unknown format")
+
+// ErrUnparsable is returned when the input can't be read by the format (or
+// by any format when none was given)
+var ErrUnparsable = parsers.ErrUnparsable
+
+// Direction is which way the input is converted
+type Direction = format.Direction
+
+const (
+	FromMachine = format.FromMachine
+	IntoMachine = format.IntoMachine
+)
+
+// Confidence is how sure a format is that the input was meant for it
+type Confidence = format.Confidence
+
+const (
+	NoConfidence     = format.NoConfidence
+	LowConfidence    = format.LowConfidence
+	MediumConfidence = format.MediumConfidence
+	HighConfidence   = format.HighConfidence
+)
+
+// Result is an interpretation of the input: what it was converted into, by
+// which format and which way
+type Result struct {
+	// Value is the input converted
+	Value string
+	// Format is the name of the format that read the input
+	Format string
+	// Direction is which way the input was converted
+	Direction Direction
+	// Confidence is how sure the format is that the input was meant for it
+	Confidence Confidence
+	// Warnings are problems with the input that didn't stop it from being
+	// converted, ie: numfmt --invalid=warn
+	Warnings []string
+}
+
+// Option changes how the input is converted
+type Option func(*settings)
+
+// settings is what the options change
+type settings struct {
+	format string
+	// typed are the format's options written in Go, the options and flags
+	// are set on top of them the same way the command line ones are
+	typed   format.Options
+	options map[string]string
+	flags   map[string]bool
+}
+
+// WithFormat picks the format the input is converted with, ie: size, time
+func WithFormat(name string) Option {
+	return func(s *settings) {
+		s.format = name
+	}
+}
+
+// WithUnits sets the units the format uses, ie: si or iec for sizes
+func WithUnits(units string) Option {
+	return WithOption("units", units)
+}
+
+// WithTo sets what the format converts into, ie: a unit or a notation
+func WithTo(to string) Option {
+	return WithOption("to", to)
+}
+
+// WithOptions gives the format its options, ie: &format.SizeOptions{Units: "si"}.
+// They're only used by the format they belong to
+func WithOptions(opts format.Options) Option {
+	return func(s *settings) {
+		s.typed = opts
+	}
+}
+
+// WithOption sets one of the format's options, they're the same ones the
+// command line has (without the dashes), ie: WithOption("tz", "UTC")
+func WithOption(name, value string) Option {
+	return func(s *settings) {
+		s.options[name] = value
+	}
+}
+
+// WithFlag sets one of the format's flags, ie: WithFlag("w") for words
+func WithFlag(name string) Option {
+	return func(s *settings) {
+		s.flags[name] = true
+	}
+}
+
+func newSettings(opts []Option) *settings {
+	s := &settings{options: map[string]string{}, flags: map[string]bool{}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// bind gives back the format's options with the settings applied, options
+// the format doesn't have (or values it can't take) are an error
+func (s *settings) bind(f format.Format) (format.Options, error) {
+	opts := f.Options()
+
+	// The typed options are copied so that setting the others on top of them
+	// doesn't change them
+	if s.typed != nil && reflect.TypeOf(s.typed) == reflect.TypeOf(opts) {
+		reflect.ValueOf(opts).Elem().Set(reflect.ValueOf(s.typed).Elem())
+		if err := format.Validate(opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := format.SetOptions(opts, io.CliArgs{Options: s.options, Flags: s.flags}); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// Humanize converts what a machine wrote into something a human can read
+func Humanize(ctx context.Context, input string, opts ...Option) (Result, error) {
+	return convert(ctx, FromMachine, input, newSettings(opts))
+}
+
+// Parse converts what a human wrote into something a machine can read
+func Parse(ctx context.Context, input string, opts ...Option) (Result, error) {
+	return convert(ctx, IntoMachine, input, newSettings(opts))
+}
+
+// convert runs the format asked for or, when there's none, gives back the
+// interpretation the formats are most sure about
+func convert(ctx context.Context, direction Direction, input string, s *settings) (Result, error) {
+	if s.format == "" {
+		results, err := all(ctx, []Direction{direction}, input, s)
+		if err != nil {
+			return Result{}, err
+		}
+		if len(results) == 0 {
+			return Result{}, ErrUnparsable
+		}
+		return results[0], nil
+	}
+
+	f := Lookup(s.format)
+	if f == nil {
+		return Result{}, fmt.Errorf("%w '%s'", ErrUnknownFormat, s.format)
+	}
+	if s.typed != nil && reflect.TypeOf(s.typed) != reflect.TypeOf(f.Options()) {
+		return Result{}, fmt.Errorf("%w: %T for %s", format.ErrWrongOptions, s.typed, s.format)
+	}
+	opts, err := s.bind(f)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+
+	out, err := f.Run(direction, input, opts)
+	res := Result{
+		Value:      out,
+		Format:     s.format,
+		Direction:  direction,
+		Confidence: format.Detect(f, direction, input),
+	}
+	res.Warnings, err = warnings(err)
+	return res, err
+}
+
+// All tries every format in both directions and gives back every
+// interpretation of the input, the ones the formats are most sure about
+// first. The input isn't known to be the machine or the human side of
+// things (ie: 1994 vs MCMXCIV) so both are tried
+func All(ctx context.Context, input string, opts ...Option) ([]Result, error) {
+	return all(ctx, []Direction{FromMachine, IntoMachine}, input, newSettings(opts))
+}
+
+func all(ctx context.Context, directions []Direction, input string, s *settings) ([]Result, error) {
+	var results []Result
+	var unusable error
+	used := false
+	for _, name := range Formats() {
+		// Formats that don't have the options given can't be the ones meant,
+		// but options no format has are a mistake
+		f := Lookup(name)
+		opts, err := s.bind(f)
+		if err != nil {
+			// The error worth telling is the one from a format that has the
+			// option (ie: a bad value), the rest just don't know about it
+			if unusable == nil || (errors.Is(unusable, format.ErrUnknownOption) && !errors.Is(err, format.ErrUnknownOption)) {
+				unusable = err
+			}
+			continue
+		}
+		used = true
+
+		for _, d := range directions {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			// Some inputs make sense for more than one format (ie: 1777 is a
+			// number and a file mode) so the outputs are ranked by how sure each
+			// format is that the input was meant for it
+			out, err := f.Run(d, input, opts)
+			confidence := format.Detect(f, d, input)
+			if out != "" && confidence != NoConfidence {
+				w, _ := warnings(err)
+				results = append(results, Result{Value: out, Format: name, Direction: d, Confidence: confidence, Warnings: w})
+			}
+		}
+	}
+
+	if !used {
+		return nil, unusable
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Confidence > results[j].Confidence
+	})
+	return results, nil
+}
+
+// warnings pulls the warnings out of the error a format gave back, they
+// aren't an error unless the conversion failed as well
+func warnings(err error) ([]string, error) {
+	var w *format.Warnings
+	if errors.As(err, &w) {
+		return w.Messages, w.Err
+	}
+	return nil, err
+}
@@ -0,0 +1,146 @@
+package human
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/andres-lowrie/human/format"
+	"github.com/andres-lowrie/human/parsers"
+)
+
+func TestHumanize(t *testing.T) {
+	tests := []struct {
+		input  string
+		opts   []Option
+		out    string
+		format string
+		err    error
+	}{
+		{"123456789", []Option{WithFormat("size"), WithUnits("si")}, "123.5MB", "size", nil},
+		{"1994", []Option{WithFormat("roman")}, "MCMXCIV", "roman", nil},
+		{"0.3333", []Option{WithFormat("ratio"), WithTo("odds")}, "about 1 in 3", "ratio", nil},
+		// Size and rate are as sure about a plain number, size goes first
+		{"123456789", []Option{WithUnits("si")}, "123.5MB", "size", nil},
+		{"0.5", []Option{WithFormat("ratio"), WithTo("decimal")}, "", "ratio", parsers.ErrUnknownRatioNotation},
+		{"hello", []Option{WithFormat("size")}, "", "size", ErrUnparsable},
+		{"1", []Option{WithFormat("nope")}, "", "", ErrUnknownFormat},
+		{"123456789", []Option{WithFormat("size"), WithOptions(&format.SizeOptions{Units: "si"})}, "123.5MB", "size", nil},
+		{"123456789", []Option{WithFormat("size"), WithOptions(&format.SizeOptions{Units: "si"}), WithTo("KB")}, "123456.8KB", "size", nil},
+		{"123456789", []Option{WithFormat("size"), WithOptions(&format.SizeOptions{Units: "metric"})}, "", "", format.ErrBadOption},
+		{"1994", []Option{WithFormat("roman"), WithOptions(&format.SizeOptions{})}, "", "", format.ErrWrongOptions},
+		{"1994", []Option{WithFormat("roman"), WithUnits("si")}, "", "", format.ErrUnknownOption},
+		// The format most sure about the input wins when none is given
+		{`{"a": 1}`, nil, "{\n  \"a\": 1\n}", "json", nil},
+		{"", nil, "", "", ErrUnparsable},
+	}
+
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Humanize(context.Background(), tt.input, tt.opts...)
+			if got.Value != tt.out || got.Format != tt.format {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` (%s) ; got `%s` (%s)", i, tt.input, tt.out, tt.format, got.Value, got.Format)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.input, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		opts  []Option
+		out   string
+		err   error
+	}{
+		{"2 hours", []Option{WithFormat("duration")}, "7200", nil},
+		{"MCMXCIV", []Option{WithFormat("roman")}, "1994", nil},
+		{"1,000", []Option{WithFormat("number")}, "1000", nil},
+		{"hello", []Option{WithFormat("roman")}, "", ErrUnparsable},
+	}
+
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(context.Background(), tt.input, tt.opts...)
+			if got.Value != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.input, tt.out, got.Value)
+			}
+			if got.Direction != IntoMachine {
+				t.Errorf("Case %d: Given = `%s` ; want direction `%s` ; got `%s`", i, tt.input, IntoMachine, got.Direction)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.input, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestAll(t *testing.T) {
+	results, err := All(context.Background(), "1777")
+	if err != nil {
+		t.Fatalf("Given = `1777` ; want no error ; got `%v`", err)
+	}
+	if len(results) < 2 {
+		t.Fatalf("Given = `1777` ; want more than one result ; got `%v`", results)
+	}
+	for i := 1; i < len(results); i++ {
+		if results[i].Confidence > results[i-1].Confidence {
+			t.Errorf("Case %d: Given = `1777` ; want results ranked by confidence ; got `%v`", i, results)
+		}
+	}
+
+	// Options no format has are a mistake, not a reason to skip every format
+	if _, err := All(context.Background(), "1777", WithOption("bogus", "1")); !errors.Is(err, format.ErrUnknownOption) {
+		t.Errorf("Error Case: Given = `--bogus` ; want `%v` ; got `%v`", format.ErrUnknownOption, err)
+	}
+
+	// A bad value is reported by the format that has the option
+	if _, err := All(context.Background(), "XIV", WithOption("lenient", "sure")); !errors.Is(err, format.ErrBadOption) {
+		t.Errorf("Error Case: Given = `--lenient=sure` ; want `%v` ; got `%v`", format.ErrBadOption, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := All(ctx, "1777"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Error Case: Given = a canceled context ; want `%v` ; got `%v`", context.Canceled, err)
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	res, err := Humanize(context.Background(), "1000\nx", WithFormat("numfmt"), WithOption("invalid", "warn"), WithTo("si"))
+	if err != nil {
+		t.Fatalf("Given = `1000\\nx` ; want no error ; got `%v`", err)
+	}
+	if res.Value != "1.0K\nx" {
+		t.Errorf("Given = `1000\\nx` ; want `1.0K\\nx` ; got `%s`", res.Value)
+	}
+	if len(res.Warnings) != 1 || res.Warnings[0] != "invalid number: 'x'" {
+		t.Errorf("Given = `1000\\nx` ; want warnings `[invalid number: 'x']` ; got `%v`", res.Warnings)
+	}
+}
+
+func TestFormats(t *testing.T) {
+	names := Formats()
+	if len(names) != len(handlers) {
+		t.Errorf("Given = `Formats()` ; want `%d` formats ; got `%d`", len(handlers), len(names))
+	}
+	for i, name := range names {
+		if _, ok := handlers[name]; !ok {
+			t.Errorf("Case %d: Given = `%s` ; want a format ; got none", i, name)
+		}
+	}
+}
+
+func TestWarningsFail(t *testing.T) {
+	res, err := Humanize(context.Background(), "1000\nx\ny", WithFormat("numfmt"), WithOption("invalid", "fail"))
+	if !errors.Is(err, parsers.ErrInvalidInput) {
+		t.Errorf("Error Case: Given = `1000\\nx\\ny` ; want `%v` ; got `%v`", parsers.ErrInvalidInput, err)
+	}
+	if len(res.Warnings) != 2 {
+		t.Errorf("Given = `1000\\nx\\ny` ; want a warning for each invalid line ; got `%v`", res.Warnings)
+	}
+}
@@ -38,8 +38,10 @@ func IsPositional(s string) bool {
 }
 
 // ParseCliArgs transforms the slice of strings passed into the program into
-// the concrete type all the parsers know how to deal with.
-func ParseCliArgs(input []string) CliArgs {
+// the concrete type all the parsers know how to deal with. Switches are the
+// options that are true or false, they only take a value with `=` (ie:
+// `--exact=false`) so that they never swallow the input that follows them
+func ParseCliArgs(input []string, switches ...string) CliArgs {
 	args := NewCliArgs()
 	for i := 0; i <= len(input)-1; i++ {
 		v := input[i]
@@ -69,7 +71,7 @@ func ParseCliArgs(input []string) CliArgs {
 			}
 
 			// Should we swallow the next positional?
-			if len(pair) != 2 {
+			if len(pair) != 2 && !isSwitch(key, switches) {
 				nextWordIdx := i + 1
 				if nextWordIdx < len(input) {
 					if IsPositional(input[nextWordIdx]) {
@@ -103,3 +105,12 @@ func ParseCliArgs(input []string) CliArgs {
 	}
 	return args
 }
+
+func isSwitch(name string, switches []string) bool {
+	for _, s := range switches {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
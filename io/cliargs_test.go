@@ -64,14 +64,16 @@ func TestIsPositional(t *testing.T) {
 
 func TestParseCliArgs(t *testing.T) {
 	tests := []struct {
-		name string
-		in   []string
-		out  CliArgs
+		name     string
+		in       []string
+		switches []string
+		out      CliArgs
 	}{
 		// human foo baz
 		{
 			"Positionals Happy Path",
 			[]string{"foo", "baz"},
+			nil,
 			CliArgs{
 				Flags:       map[string]bool{},
 				Options:     map[string]string{},
@@ -82,6 +84,7 @@ func TestParseCliArgs(t *testing.T) {
 		{
 			"Options Happy Path",
 			[]string{"--foo=bar"},
+			nil,
 			CliArgs{
 				Flags:       map[string]bool{},
 				Options:     map[string]string{"foo": "bar"},
@@ -92,6 +95,7 @@ func TestParseCliArgs(t *testing.T) {
 		{
 			"Options without values",
 			[]string{"--foo"},
+			nil,
 			CliArgs{
 				Flags:       map[string]bool{},
 				Options:     map[string]string{"foo": ""},
@@ -102,6 +106,7 @@ func TestParseCliArgs(t *testing.T) {
 		{
 			"Options swallow next positional",
 			[]string{"--foo", "bar", "--baz"},
+			nil,
 			CliArgs{
 				Flags:       map[string]bool{},
 				Options:     map[string]string{"foo": "bar", "baz": ""},
@@ -112,6 +117,7 @@ func TestParseCliArgs(t *testing.T) {
 		{
 			"Options last option wins",
 			[]string{"--foo", "bar", "--foo", "baz"},
+			nil,
 			CliArgs{
 				Flags:       map[string]bool{},
 				Options:     map[string]string{"foo": "baz"},
@@ -122,6 +128,7 @@ func TestParseCliArgs(t *testing.T) {
 		{
 			"Flags Happy Path",
 			[]string{"-f"},
+			nil,
 			CliArgs{
 				Flags:       map[string]bool{"f": true},
 				Options:     map[string]string{},
@@ -132,6 +139,7 @@ func TestParseCliArgs(t *testing.T) {
 		{
 			"Flags Happy Path",
 			[]string{"-b", "-a", "-r"},
+			nil,
 			CliArgs{
 				Flags:       map[string]bool{"b": true, "a": true, "r": true},
 				Options:     map[string]string{},
@@ -142,6 +150,7 @@ func TestParseCliArgs(t *testing.T) {
 		{
 			"Flags handle shorthand",
 			[]string{"-bar"},
+			nil,
 			CliArgs{
 				Flags:       map[string]bool{"b": true, "a": true, "r": true},
 				Options:     map[string]string{},
@@ -152,15 +161,28 @@ func TestParseCliArgs(t *testing.T) {
 		{
 			"Repeated flags get counted instead of bools",
 			[]string{"-vvv"},
+			nil,
 			CliArgs{
 				Flags:       map[string]bool{},
 				Options:     map[string]string{"v": "3"},
 				Positionals: []string{},
 			},
 		},
+		// human --exact 1234
+		{
+			"Switches don't swallow the next positional",
+			[]string{"--exact", "1234", "--lenient=false"},
+			[]string{"exact", "lenient"},
+			CliArgs{
+				Flags:       map[string]bool{},
+				Options:     map[string]string{"exact": "", "lenient": "false"},
+				Positionals: []string{"1234"},
+			},
+		},
 		{
 			"Kitchen Sink",
 			[]string{"first", "-foo", "-b", "-a", "-r", "--opt", "--foo=bar", "baz", "--long", "wat", "last"},
+			nil,
 			CliArgs{
 				Flags:       map[string]bool{"f": true, "b": true, "a": true, "r": true},
 				Options:     map[string]string{"foo": "bar", "opt": "", "long": "wat", "o": "2"},
@@ -170,7 +192,7 @@ func TestParseCliArgs(t *testing.T) {
 	}
 	for i, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := ParseCliArgs(tt.in)
+			got := ParseCliArgs(tt.in, tt.switches...)
 			conds := []bool{
 				reflect.DeepEqual(got.Flags, tt.out.Flags),
 				reflect.DeepEqual(got.Options, tt.out.Options),
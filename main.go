@@ -1,42 +1,52 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/andres-lowrie/human/format"
+	"github.com/andres-lowrie/human/human"
 	"github.com/andres-lowrie/human/io"
 	"github.com/davecgh/go-spew/spew"
 )
 
-func run(log io.Ourlog, args io.CliArgs) {
+func run(log io.Ourlog, args io.CliArgs) error {
 	log.Debug("Program start")
 	log.Debug(spew.Sdump(args))
 
-	// The idea here is that human will print out all parseable values for each
-	// known parser (the below map); ie: arguments are used to make it more
-	// specific similar to `dig`, where `dig` with no args gives all the
-	// information it has, and then something like `dig +short` gives you a whole
-	// lot less
-	// @TODO see if we can use GetParsers instead of instantiating directly
-	handlers := map[string]format.Format{"number": format.NewNumber(), "size": format.NewSize()}
-
 	// Figure out direction and which format
 	// we'll default to the `--from` direction since it might be the most common
-	// usecase i.e. we want to go "from" machine into human format
-	if len(args.Positionals) < 1 {
-		log.Warn("@TODO read arguments from stdin")
-		return
+	// usecase i.e. we want to go "from" machine into human format.
+	//
+	// Only known formats count as a direction, that way formats can have their
+	// own `--from` and `--into` options (ie: `numfmt --from=si`)
+	direction := human.FromMachine
+	chosen := ""
+	selector := ""
+	for _, d := range []human.Direction{human.IntoMachine, human.FromMachine} {
+		if val, ok := args.Options[d.String()]; ok && human.Lookup(val) != nil {
+			direction = d
+			chosen = val
+			selector = d.String()
+		}
 	}
 
-	input := args.Positionals[0]
-	direction := "from"
-	format := ""
-	for _, d := range []string{"into", "from"} {
-		if val, ok := args.Options[d]; ok && val != "" {
-			direction = d
-			format = val
+	// The rest of the options and flags are the format's, the ones it doesn't
+	// have are an error
+	var opts []human.Option
+	for name, value := range args.Options {
+		if name != "v" && name != selector {
+			opts = append(opts, human.WithOption(name, value))
+		}
+	}
+	for name := range args.Flags {
+		if name != "v" {
+			opts = append(opts, human.WithFlag(name))
 		}
 	}
 
@@ -44,44 +54,130 @@ func run(log io.Ourlog, args io.CliArgs) {
 	// then the first positional argument (read left from right) is the format
 	// and anything after that is the actual input, however if only 1 positional
 	// argument was given then that must be the input in which case we should run
-	// all the possible translations, this is why we're checking format for
-	// emptiness twice
-	if format == "" {
-		if len(args.Positionals) > 1 {
-			format = args.Positionals[0]
-			input = args.Positionals[1]
+	// all the possible translations. The exception is when the input is being
+	// piped in, then a lone positional that names a format is the format
+	inputs := args.Positionals
+	piped := isPiped()
+	if chosen == "" && len(inputs) > 0 {
+		if len(inputs) > 1 || (human.Lookup(inputs[0]) != nil && piped) {
+			chosen = inputs[0]
+			inputs = inputs[1:]
+		}
+	}
+
+	// A `--into` or `--from` that isn't an option of the format names the
+	// format, so one that doesn't exist is a mistake
+	for _, d := range []human.Direction{human.IntoMachine, human.FromMachine} {
+		val, ok := args.Options[d.String()]
+		if !ok || d.String() == selector {
+			continue
+		}
+		owner := human.Lookup(chosen)
+		if owner == nil || errors.Is(format.SetOption(owner.Options(), d.String(), val), format.ErrUnknownOption) {
+			return fmt.Errorf("%w '%s'", human.ErrUnknownFormat, val)
+		}
+	}
+
+	if len(inputs) < 1 {
+		if !piped {
+			log.Warn("nothing to do, no input given")
+			return nil
 		}
+
+		stdin, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		inputs = []string{strings.TrimRight(string(stdin), "\n")}
+	}
+
+	// The swatch of a color is only shown on terminals, formats don't know
+	// where their output goes. Options given on the command line still win
+	if isTerminal() && (chosen == "" || chosen == "color") {
+		opts = append(opts, human.WithOptions(&format.ColorOptions{Swatch: true}))
 	}
-	log.Info("format is set to: ", format)
-	log.Info("input is set to: ", input)
+
+	log.Info("format is set to: ", chosen)
+	log.Info("inputs are set to: ", inputs)
 	log.Info("direction is set to: ", direction)
 
-	var output string
-	if format == "" {
-		for _, c := range handlers {
-			output, _ = c.Run(direction, input, args)
-			if output != "" {
-				fmt.Println(output)
+	ctx := context.Background()
+
+	// The idea here is that human will print out all parseable values for each
+	// known format; ie: arguments are used to make it more specific similar to
+	// `dig`, where `dig` with no args gives all the information it has, and then
+	// something like `dig +short` gives you a whole lot less
+	if chosen == "" {
+		for _, input := range inputs {
+			results, err := human.All(ctx, input, opts...)
+			if err != nil {
+				return err
+			}
+			for _, r := range results {
+				log.Info("format ", r.Format, " ", r.Direction, " confidence ", r.Confidence)
+				warn(r.Warnings)
+				fmt.Println(r.Value)
 			}
 		}
-		return
+		return nil
 	}
 
-	c, ok := handlers[format]
-	if !ok {
-		log.Info("unknown format '%s', nothing to do", format)
-		return
+	convert := human.Humanize
+	if direction == human.IntoMachine {
+		convert = human.Parse
 	}
+	opts = append(opts, human.WithFormat(chosen))
 
-	output, _ = c.Run(direction, input, args)
-	if output != "" {
-		fmt.Println(output)
+	// Since a format was asked for any error is worth reporting, but we still
+	// go through all the inputs (and print whatever came out) like most tools do
+	var failed error
+	for _, input := range inputs {
+		res, err := convert(ctx, input, opts...)
+		if errors.Is(err, human.ErrUnknownFormat) {
+			return err
+		}
+		warn(res.Warnings)
+		if res.Value != "" {
+			fmt.Println(res.Value)
+		}
+		if err != nil && !errors.Is(err, human.ErrUnparsable) {
+			failed = err
+		}
 	}
 
+	return failed
+}
+
+// warn prints the warnings a format had about the input, they go to stderr so
+// that they don't mix with the output
+func warn(warnings []string) {
+	for _, w := range warnings {
+		fmt.Fprintln(os.Stderr, "human:", w)
+	}
+}
+
+// isTerminal determines if the output goes to a terminal rather than a pipe
+// or a file
+func isTerminal() bool {
+	stat, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+// isPiped determines if something is being sent into the program through
+// stdin, ie: `cat file | human numfmt --to=si`
+func isPiped() bool {
+	stat, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice == 0
 }
 
 func main() {
-	args := io.ParseCliArgs(os.Args[1:])
+	args := io.ParseCliArgs(os.Args[1:], human.Switches()...)
 	log := io.NewLogger(io.OFF, false)
 
 	// Figure out if we have to enable the logger
@@ -100,5 +196,8 @@ func main() {
 		}
 	}
 
-	run(log, args)
+	if err := run(log, args); err != nil {
+		fmt.Fprintln(os.Stderr, "human:", err)
+		os.Exit(2)
+	}
 }
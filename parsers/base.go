@@ -0,0 +1,273 @@
+package parsers
+
+import (
+	"errors"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrBadBase error = errors.New("Base must be between 2 and 36")
+var ErrNotALiteral error = errors.New("Not a prefixed number literal, ie: 0x, 0o, 0b or base#digits")
+
+// basePrefixes are the literal prefixes Go (and most other languages) use
+var basePrefixes = map[int]string{
+	2:  "0b",
+	8:  "0o",
+	16: "0x",
+}
+
+// baseGroups is how many digits go in each group when a group size isn't
+// given, 0 means no grouping
+var baseGroups = map[int]int{
+	2:  4,
+	8:  3,
+	10: 3,
+	16: 4,
+}
+
+// Base converts numbers between radixes. The machine side of things is always
+// a decimal number, the human side is a literal written in some other base
+// with its prefix and its digits grouped, ie:
+// 	3735928559 <-> 0xdead_beef
+//
+// Bases that don't have a prefix use the bash notation `base#digits`
+type Base struct {
+	// toBase is the base used when converting from a machine number
+	toBase int
+	// bits is the width used to show two's complement values, 0 means the
+	// values are unbounded
+	bits int
+	// group is the amount of digits per group, -1 means use the default for
+	// the base and 0 means don't group
+	group int
+}
+
+// NewBase constructs a Base parser
+func NewBase(toBase, bits, group int) *Base {
+	return &Base{toBase: toBase, bits: bits, group: group}
+}
+
+// CanParseFromMachine determines if the input is a number we can write in the
+// target base. Besides decimal numbers any prefixed literal is allowed as well
+// which allows going between bases, ie: 0x7fff -> 0b0111_1111_1111_1111
+func (b *Base) CanParseFromMachine(s string) (bool, error) {
+	if b.toBase < 2 || b.toBase > 36 {
+		return false, ErrBadBase
+	}
+
+	n, err := parseBaseLiteral(s, true)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := b.toUnsigned(n); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CanParseIntoMachine determines if the input is a prefixed literal
+func (b *Base) CanParseIntoMachine(s string) (bool, error) {
+	n, err := parseBaseLiteral(s, false)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := b.toSigned(n); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// DoFromMachine writes the number in the target base. When a bit width is set
+// negative numbers are written in two's complement and the output is padded to
+// the full width
+func (b *Base) DoFromMachine(s string) (string, error) {
+	if ok, err := b.CanParseFromMachine(s); !ok {
+		return "", err
+	}
+
+	n, _ := parseBaseLiteral(s, true)
+	n, _ = b.toUnsigned(n)
+
+	sign := ""
+	if n.Sign() < 0 {
+		sign = "-"
+		n.Neg(n)
+	}
+
+	digits := n.Text(b.toBase)
+	if b.bits > 0 {
+		width := len(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(b.bits)), big.NewInt(1)).Text(b.toBase))
+		if len(digits) < width {
+			digits = strings.Repeat("0", width-len(digits)) + digits
+		}
+	}
+
+	size := b.group
+	if size < 0 {
+		size = baseGroups[b.toBase]
+	}
+
+	return sign + baseLiteralPrefix(b.toBase) + groupDigits(digits, size), nil
+}
+
+// DoIntoMachine gives back the decimal value of the literal. When a bit width
+// is set the literal is read as a two's complement value, ie: 0xff with 8 bits
+// is -1
+func (b *Base) DoIntoMachine(s string) (string, error) {
+	n, err := parseBaseLiteral(s, false)
+	if err != nil {
+		return "", err
+	}
+
+	n, err = b.toSigned(n)
+	if err != nil {
+		return "", err
+	}
+
+	return n.String(), nil
+}
+
+// Lossless is true unless there's a width, then numbers past the signed range
+// are read back as negative ones, ie: 255 -> 0xff -> -1 with 8 bits
+func (b *Base) Lossless() bool {
+	return b.bits <= 0
+}
+
+// toUnsigned fits the number into the bit width turning negative numbers into
+// their two's complement
+func (b *Base) toUnsigned(n *big.Int) (*big.Int, error) {
+	if b.bits <= 0 {
+		return n, nil
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(b.bits))
+	half := new(big.Int).Rsh(limit, 1)
+
+	if n.Cmp(limit) >= 0 {
+		return nil, ErrTooLarge
+	}
+
+	if n.Sign() < 0 {
+		if n.Cmp(new(big.Int).Neg(half)) < 0 {
+			return nil, ErrTooSmall
+		}
+		return new(big.Int).Add(n, limit), nil
+	}
+
+	return n, nil
+}
+
+// toSigned reads the number as a two's complement value of the bit width
+func (b *Base) toSigned(n *big.Int) (*big.Int, error) {
+	if b.bits <= 0 {
+		return n, nil
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(b.bits))
+	half := new(big.Int).Rsh(limit, 1)
+
+	if n.Sign() < 0 || n.Cmp(limit) >= 0 {
+		return nil, ErrTooLarge
+	}
+
+	if n.Cmp(half) >= 0 {
+		return new(big.Int).Sub(n, limit), nil
+	}
+
+	return n, nil
+}
+
+// parseBaseLiteral reads a number written as:
+// 	0x..., 0o..., 0b...  prefixed literals
+// 	base#digits          bash style literals
+// 	digits               decimal numbers, only when `allowDecimal` is set
+//
+// Underscores are allowed in between digits and the number can be negative
+func parseBaseLiteral(s string, allowDecimal bool) (*big.Int, error) {
+	s = strings.TrimSpace(s)
+	r := regexp.MustCompile(`(?i)^(-)?(0x|0o|0b|[0-9]{1,2}#)?([0-9a-z]+(_[0-9a-z]+)*)$`)
+	match := r.FindStringSubmatch(s)
+	if match == nil {
+		if allowDecimal {
+			return nil, ErrNotANumber
+		}
+		return nil, ErrNotALiteral
+	}
+
+	sign, prefix, digits := match[1], strings.ToLower(match[2]), strings.Replace(match[3], "_", "", -1)
+
+	base := 10
+	switch prefix {
+	case "0x":
+		base = 16
+	case "0o":
+		base = 8
+	case "0b":
+		base = 2
+	case "":
+		if !allowDecimal {
+			return nil, ErrNotALiteral
+		}
+	default:
+		b, _ := strconv.Atoi(strings.TrimSuffix(prefix, "#"))
+		if b < 2 || b > 36 {
+			return nil, ErrBadBase
+		}
+		base = b
+	}
+
+	n, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		if prefix == "" {
+			return nil, ErrNotANumber
+		}
+		return nil, ErrNotALiteral
+	}
+
+	if sign != "" {
+		n.Neg(n)
+	}
+
+	return n, nil
+}
+
+// baseLiteralPrefix gives back how a literal in the base starts
+func baseLiteralPrefix(base int) string {
+	if p, ok := basePrefixes[base]; ok {
+		return p
+	}
+
+	if base == 10 {
+		return ""
+	}
+
+	return strconv.Itoa(base) + "#"
+}
+
+// groupDigits adds an underscore every `size` digits starting from the right
+func groupDigits(digits string, size int) string {
+	if size <= 0 || len(digits) <= size {
+		return digits
+	}
+
+	var out strings.Builder
+	lead := len(digits) % size
+	if lead > 0 {
+		out.WriteString(digits[:lead])
+	}
+
+	for i := lead; i < len(digits); i += size {
+		if out.Len() > 0 {
+			out.WriteRune('_')
+		}
+		out.WriteString(digits[i : i+size])
+	}
+
+	return out.String()
+}
@@ -0,0 +1,161 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestBaseCanParseFromMachine(t *testing.T) {
+	tests := []struct {
+		toBase int
+		bits   int
+		in     string
+		out    bool
+		err    error
+	}{
+		{16, 0, "abc", false, ErrNotANumber},
+		{16, 0, "12af", false, ErrNotANumber},
+		{16, 0, "0xzz", false, ErrNotALiteral},
+		{1, 0, "10", false, ErrBadBase},
+		{37, 0, "10", false, ErrBadBase},
+		{16, 0, "10", true, nil},
+		{16, 0, "1_000", true, nil},
+		{16, 0, "0b1010", true, nil},
+		{16, 0, "36#zz", true, nil},
+		{16, 0, "99#zz", false, ErrBadBase},
+		// Arbitrarily large
+		{16, 0, "123456789012345678901234567890123456789012345678901234567890", true, nil},
+		// Has to fit in the bits
+		{16, 8, "255", true, nil},
+		{16, 8, "256", false, ErrTooLarge},
+		{16, 8, "-128", true, nil},
+		{16, 8, "-129", false, ErrTooSmall},
+	}
+
+	for i, tt := range tests {
+		base := NewBase(tt.toBase, tt.bits, -1)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := base.CanParseFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestBaseCanParseIntoMachine(t *testing.T) {
+	tests := []struct {
+		bits int
+		in   string
+		out  bool
+		err  error
+	}{
+		// Decimals aren't literals
+		{0, "1000", false, ErrNotALiteral},
+		{0, "abc", false, ErrNotALiteral},
+		{0, "0x", false, ErrNotALiteral},
+		{0, "0b102", false, ErrNotALiteral},
+		{0, "0x_ff", false, ErrNotALiteral},
+		{0, "0xff_", false, ErrNotALiteral},
+		{0, "0x7fff", true, nil},
+		{0, "0X7FFF", true, nil},
+		{0, "0o755", true, nil},
+		{0, "0b1010_0101", true, nil},
+		{0, "36#zz", true, nil},
+		{0, "0xdead_beef", true, nil},
+		{8, "0x1ff", false, ErrTooLarge},
+	}
+
+	for i, tt := range tests {
+		base := NewBase(16, tt.bits, -1)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := base.CanParseIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestBaseDoFromMachine(t *testing.T) {
+	tests := []struct {
+		toBase int
+		bits   int
+		group  int
+		in     string
+		out    string
+		err    error
+	}{
+		{16, 0, -1, "32767", "0x7fff", nil},
+		{16, 0, -1, "3735928559", "0xdead_beef", nil},
+		{16, 0, 0, "3735928559", "0xdeadbeef", nil},
+		{16, 0, 2, "3735928559", "0xde_ad_be_ef", nil},
+		{2, 0, -1, "165", "0b1010_0101", nil},
+		{2, 0, -1, "5", "0b101", nil},
+		{8, 0, -1, "493", "0o755", nil},
+		{10, 0, -1, "0xffffffff", "4_294_967_295", nil},
+		{36, 0, -1, "1295", "36#zz", nil},
+		{16, 0, -1, "-255", "-0xff", nil},
+		{16, 0, -1, "340282366920938463463374607431768211455", "0xffff_ffff_ffff_ffff_ffff_ffff_ffff_ffff", nil},
+		// Two's complement
+		{16, 8, -1, "-1", "0xff", nil},
+		{2, 8, -1, "-128", "0b1000_0000", nil},
+		{2, 8, -1, "5", "0b0000_0101", nil},
+		{16, 32, -1, "-2", "0xffff_fffe", nil},
+		{8, 8, -1, "1", "0o001", nil},
+		{16, 8, -1, "256", "", ErrTooLarge},
+	}
+
+	for i, tt := range tests {
+		base := NewBase(tt.toBase, tt.bits, tt.group)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := base.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestBaseDoIntoMachine(t *testing.T) {
+	tests := []struct {
+		bits int
+		in   string
+		out  string
+		err  error
+	}{
+		{0, "0x7fff", "32767", nil},
+		{0, "0o755", "493", nil},
+		{0, "0b1010_0101", "165", nil},
+		{0, "36#zz", "1295", nil},
+		{0, "2#101", "5", nil},
+		{0, "0xffff_ffff_ffff_ffff_ffff_ffff_ffff_ffff", "340282366920938463463374607431768211455", nil},
+		{8, "0xff", "-1", nil},
+		{8, "0x7f", "127", nil},
+		{8, "0x80", "-128", nil},
+		{16, "0xff", "255", nil},
+		{8, "0x100", "", ErrTooLarge},
+		{0, "1000", "", ErrNotALiteral},
+	}
+
+	for i, tt := range tests {
+		base := NewBase(16, tt.bits, -1)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := base.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package parsers
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"math/bits"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrNotABitmask error = errors.New("Not a bitmask, ie: 0x1a, 0b11010 or 26")
+var ErrBadBitSchema error = errors.New("Can't read bit schema")
+var ErrUnknownBitSchema error = errors.New("Unknown bit schema")
+var ErrUnknownFlag error = errors.New("Unknown flag")
+
+//go:embed bits/*.txt
+var builtinBitSchemas embed.FS
+
+// bitFlag is a name for a value of the bits in the mask, for single bit flags
+// the mask is the value itself
+type bitFlag struct {
+	name  string
+	value uint64
+	mask  uint64
+	// alias flags are only read, never written (ie: IN_CLOSE is written as
+	// IN_CLOSE_WRITE|IN_CLOSE_NOWRITE)
+	alias bool
+}
+
+// BitSchema names the bits of a bitmask, it's read from text with one flag
+// per line:
+// 	NAME = <value> [mask <mask>] [alias]
+//
+// Values can be written in decimal, hex (0x), binary (0b) or octal (0). The
+// mask is for flags that are a value of a few bits rather than a bit of their
+// own, ie: O_RDONLY, O_WRONLY and O_RDWR are 0, 1 and 2 in the mask 03
+type BitSchema struct {
+	flags []bitFlag
+}
+
+// ParseBitSchema reads the schema from text, empty lines and lines starting
+// with # are skipped
+func ParseBitSchema(text string) (*BitSchema, error) {
+	schema := &BitSchema{}
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		m := regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(\S+)(?:\s+mask\s+(\S+))?(\s+alias)?$`).FindStringSubmatch(line)
+		if m == nil {
+			return nil, fmt.Errorf("%w: line %d '%s'", ErrBadBitSchema, n, line)
+		}
+
+		flag := bitFlag{name: m[1], alias: m[4] != ""}
+		value, err := strconv.ParseUint(m[2], 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d '%s'", ErrBadBitSchema, n, line)
+		}
+		flag.value, flag.mask = value, value
+		if m[3] != "" {
+			if flag.mask, err = strconv.ParseUint(m[3], 0, 64); err != nil || value&^flag.mask != 0 {
+				return nil, fmt.Errorf("%w: line %d '%s'", ErrBadBitSchema, n, line)
+			}
+		}
+		schema.flags = append(schema.flags, flag)
+	}
+	return schema, nil
+}
+
+// BuiltinBitSchema gives back one of the schemas that come with human
+func BuiltinBitSchema(name string) (*BitSchema, error) {
+	text, err := builtinBitSchemas.ReadFile("bits/" + name + ".txt")
+	if err != nil {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownBitSchema, name)
+	}
+	return ParseBitSchema(string(text))
+}
+
+// BuiltinBitSchemaNames lists the schemas that come with human
+func BuiltinBitSchemaNames() []string {
+	entries, _ := builtinBitSchemas.ReadDir("bits")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".txt"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Bits shows which bits of a number are set, the machine side of things is
+// the number (ie: 0x1a) and the human side is the set bits, or the names of
+// the flags when there's a schema for them (ie: SYN|ACK)
+type Bits struct {
+	// schema names the bits, when it's nil the bits are shown by position
+	schema *BitSchema
+}
+
+// NewBits constructs a Bits parser, schema can be nil
+func NewBits(schema *BitSchema) *Bits {
+	return &Bits{schema: schema}
+}
+
+// CanParseFromMachine determines if the input is a number, a `key=` in front
+// of it is fine since that's how it usually shows up in logs
+func (b *Bits) CanParseFromMachine(s string) (bool, error) {
+	if _, err := parseBitmask(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CanParseIntoMachine determines if the input is made of the schema's flags
+func (b *Bits) CanParseIntoMachine(s string) (bool, error) {
+	if _, err := b.parseFlags(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DoFromMachine writes the set bits, the ones the schema doesn't name are
+// written as a number at the end
+func (b *Bits) DoFromMachine(s string) (string, error) {
+	value, err := parseBitmask(s)
+	if err != nil {
+		return "", err
+	}
+
+	if b.schema == nil {
+		var set []string
+		for i := 0; i < 64; i++ {
+			if value&(1<<uint(i)) != 0 {
+				set = append(set, strconv.Itoa(i))
+			}
+		}
+		if len(set) == 0 {
+			set = []string{"none"}
+		}
+		return alignLines([][2]string{
+			{"set", strings.Join(set, ", ")},
+			{"binary", "0b" + groupDigits(strconv.FormatUint(value, 2), 4)},
+			{"hex", "0x" + strconv.FormatUint(value, 16)},
+		}), nil
+	}
+
+	// Flags made of more bits go first so that O_SYNC takes O_DSYNC's bit
+	// rather than showing both, bits already named aren't named again
+	flags := make([]bitFlag, 0, len(b.schema.flags))
+	for _, f := range b.schema.flags {
+		if !f.alias && f.mask != 0 {
+			flags = append(flags, f)
+		}
+	}
+	sort.SliceStable(flags, func(i, j int) bool {
+		return bits.OnesCount64(flags[i].mask) > bits.OnesCount64(flags[j].mask)
+	})
+
+	var named []bitFlag
+	claimed := uint64(0)
+	for _, f := range flags {
+		if value&f.mask == f.value && claimed&f.mask == 0 {
+			named = append(named, f)
+			claimed |= f.mask
+		}
+	}
+
+	// Written from the lowest bit up, like the flags are usually listed
+	sort.SliceStable(named, func(i, j int) bool {
+		return bits.TrailingZeros64(named[i].mask) < bits.TrailingZeros64(named[j].mask)
+	})
+
+	var out []string
+	for _, f := range named {
+		out = append(out, f.name)
+	}
+	if rest := value &^ claimed; rest != 0 {
+		out = append(out, "0x"+strconv.FormatUint(rest, 16))
+	}
+	if len(out) == 0 {
+		return "0", nil
+	}
+	return strings.Join(out, "|"), nil
+}
+
+// DoIntoMachine gives back the number the flags make up
+func (b *Bits) DoIntoMachine(s string) (string, error) {
+	value, err := b.parseFlags(s)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatUint(value, 10), nil
+}
+
+// Lossless is true when there's a schema, the bits it doesn't name are
+// written as a number so they're kept too
+func (b *Bits) Lossless() bool {
+	return b.schema != nil
+}
+
+// parseFlags ORs together the flags, which can be separated by |, commas, +
+// or spaces. Names aren't case sensitive and numbers can be mixed in
+func (b *Bits) parseFlags(s string) (uint64, error) {
+	if b.schema == nil {
+		return 0, ErrUnparsable
+	}
+
+	parts := regexp.MustCompile(`\s*[|,+\s]\s*`).Split(strings.TrimSpace(s), -1)
+	value := uint64(0)
+	for _, part := range parts {
+		if part == "" {
+			return 0, ErrUnknownFlag
+		}
+
+		found := false
+		for _, f := range b.schema.flags {
+			if strings.EqualFold(f.name, part) {
+				value, found = value|f.value, true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+
+		n, err := strconv.ParseUint(part, 0, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: '%s'", ErrUnknownFlag, part)
+		}
+		value |= n
+	}
+
+	// A lone number isn't flags, that's the machine side of things
+	if _, err := strconv.ParseUint(strings.TrimSpace(s), 0, 64); err == nil {
+		return 0, ErrUnparsable
+	}
+	return value, nil
+}
+
+// parseBitmask reads the number in any of Go's notations, ie: 26, 0x1a,
+// 0b11010, 0o32 or 032, optionally after a `key=` (ie: flags=0x1a)
+func parseBitmask(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	s = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*[=:]\s*`).ReplaceAllString(s, "")
+	value, err := strconv.ParseUint(s, 0, 64)
+	if err != nil {
+		return 0, ErrNotABitmask
+	}
+	return value, nil
+}
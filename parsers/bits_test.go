@@ -0,0 +1,113 @@
+package parsers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBitsDoFromMachine(t *testing.T) {
+	tests := []struct {
+		schema string
+		in     string
+		out    string
+		err    error
+	}{
+		{"", "0x1a", "set:       1, 3, 4\nbinary:    0b1_1010\nhex:       0x1a", nil},
+		{"", "flags=26", "set:       1, 3, 4\nbinary:    0b1_1010\nhex:       0x1a", nil},
+		{"", "0", "set:       none\nbinary:    0b0\nhex:       0x0", nil},
+		{"tcp", "0x12", "SYN|ACK", nil},
+		{"tcp", "flags=0x212", "SYN|ACK|0x200", nil},
+		{"tcp", "0", "0", nil},
+		{"open", "0101102", "O_RDWR|O_CREAT|O_TRUNC|O_LARGEFILE", nil},
+		{"open", "0", "O_RDONLY", nil},
+		// O_SYNC includes O_DSYNC's bit
+		{"open", "04010001", "O_WRONLY|O_SYNC", nil},
+		{"open", "020200000", "O_RDONLY|O_TMPFILE", nil},
+		{"mount", "0x5000", "MS_BIND|MS_REC", nil},
+		{"inotify", "0x40000100", "IN_CREATE|IN_ISDIR", nil},
+		{"inotify", "0x18", "IN_CLOSE_WRITE|IN_CLOSE_NOWRITE", nil},
+		{"", "0xzz", "", ErrNotABitmask},
+		{"", "SYN", "", ErrNotABitmask},
+	}
+
+	for i, tt := range tests {
+		var schema *BitSchema
+		if tt.schema != "" {
+			schema, _ = BuiltinBitSchema(tt.schema)
+		}
+		b := NewBits(schema)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := b.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestBitsDoIntoMachine(t *testing.T) {
+	tests := []struct {
+		schema string
+		in     string
+		out    string
+		err    error
+	}{
+		{"tcp", "SYN|ACK", "18", nil},
+		{"tcp", "syn, ack", "18", nil},
+		{"tcp", "SYN+ACK+0x200", "530", nil},
+		{"open", "O_WRONLY|O_CREAT|O_TRUNC", "577", nil},
+		{"inotify", "IN_CLOSE|IN_ISDIR", "1073741848", nil},
+		{"tcp", "SYN|FOO", "", ErrUnknownFlag},
+		{"tcp", "SYN||ACK", "", ErrUnknownFlag},
+		{"tcp", "18", "", ErrUnparsable},
+		{"", "SYN|ACK", "", ErrUnparsable},
+	}
+
+	for i, tt := range tests {
+		var schema *BitSchema
+		if tt.schema != "" {
+			schema, _ = BuiltinBitSchema(tt.schema)
+		}
+		b := NewBits(schema)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := b.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestParseBitSchema(t *testing.T) {
+	tests := []struct {
+		in  string
+		err error
+	}{
+		{"# comment\n\nREAD = 4\nWRITE = 0b10\nEXEC = 01", nil},
+		{"MODE_A = 0 mask 0x3\nMODE_B = 1 mask 0x3\nALL = 0x7 alias", nil},
+		{"READ 4", ErrBadBitSchema},
+		{"READ = four", ErrBadBitSchema},
+		{"READ = 4 mask 3", ErrBadBitSchema},
+	}
+
+	for i, tt := range tests {
+		if _, err := ParseBitSchema(tt.in); !errors.Is(err, tt.err) {
+			t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, tt.err, err)
+		}
+	}
+
+	if _, err := BuiltinBitSchema("nope"); !errors.Is(err, ErrUnknownBitSchema) {
+		t.Errorf("want `%v` ; got `%v`", ErrUnknownBitSchema, err)
+	}
+	for _, name := range BuiltinBitSchemaNames() {
+		if _, err := BuiltinBitSchema(name); err != nil {
+			t.Errorf("Builtin schema `%s` ; got `%v`", name, err)
+		}
+	}
+}
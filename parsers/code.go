@@ -0,0 +1,435 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrNotACode error = errors.New("Not a known code, ie: 503, 137, ECONNREFUSED or SIGTERM")
+var ErrUnknownCodeKind error = errors.New("Unknown kind of code, use one of: http, errno, signal, exit")
+
+// codeKinds are the tables we know about in the order they're looked up
+var codeKinds = []string{"http", "errno", "signal", "exit"}
+
+// codeEntry is a symbolic name (ie: ENOENT, SIGKILL) and what it means
+type codeEntry struct {
+	name        string
+	description string
+}
+
+// errnos are Linux's error numbers (asm-generic/errno.h), most other systems
+// agree on the first 34
+var errnos = map[int]codeEntry{
+	1:   {"EPERM", "Operation not permitted"},
+	2:   {"ENOENT", "No such file or directory"},
+	3:   {"ESRCH", "No such process"},
+	4:   {"EINTR", "Interrupted system call"},
+	5:   {"EIO", "I/O error"},
+	6:   {"ENXIO", "No such device or address"},
+	7:   {"E2BIG", "Argument list too long"},
+	8:   {"ENOEXEC", "Exec format error"},
+	9:   {"EBADF", "Bad file number"},
+	10:  {"ECHILD", "No child processes"},
+	11:  {"EAGAIN", "Try again"},
+	12:  {"ENOMEM", "Out of memory"},
+	13:  {"EACCES", "Permission denied"},
+	14:  {"EFAULT", "Bad address"},
+	15:  {"ENOTBLK", "Block device required"},
+	16:  {"EBUSY", "Device or resource busy"},
+	17:  {"EEXIST", "File exists"},
+	18:  {"EXDEV", "Cross-device link"},
+	19:  {"ENODEV", "No such device"},
+	20:  {"ENOTDIR", "Not a directory"},
+	21:  {"EISDIR", "Is a directory"},
+	22:  {"EINVAL", "Invalid argument"},
+	23:  {"ENFILE", "File table overflow"},
+	24:  {"EMFILE", "Too many open files"},
+	25:  {"ENOTTY", "Not a typewriter"},
+	26:  {"ETXTBSY", "Text file busy"},
+	27:  {"EFBIG", "File too large"},
+	28:  {"ENOSPC", "No space left on device"},
+	29:  {"ESPIPE", "Illegal seek"},
+	30:  {"EROFS", "Read-only file system"},
+	31:  {"EMLINK", "Too many links"},
+	32:  {"EPIPE", "Broken pipe"},
+	33:  {"EDOM", "Math argument out of domain of func"},
+	34:  {"ERANGE", "Math result not representable"},
+	35:  {"EDEADLK", "Resource deadlock would occur"},
+	36:  {"ENAMETOOLONG", "File name too long"},
+	37:  {"ENOLCK", "No record locks available"},
+	38:  {"ENOSYS", "Invalid system call number"},
+	39:  {"ENOTEMPTY", "Directory not empty"},
+	40:  {"ELOOP", "Too many symbolic links encountered"},
+	42:  {"ENOMSG", "No message of desired type"},
+	43:  {"EIDRM", "Identifier removed"},
+	44:  {"ECHRNG", "Channel number out of range"},
+	45:  {"EL2NSYNC", "Level 2 not synchronized"},
+	46:  {"EL3HLT", "Level 3 halted"},
+	47:  {"EL3RST", "Level 3 reset"},
+	48:  {"ELNRNG", "Link number out of range"},
+	49:  {"EUNATCH", "Protocol driver not attached"},
+	50:  {"ENOCSI", "No CSI structure available"},
+	51:  {"EL2HLT", "Level 2 halted"},
+	52:  {"EBADE", "Invalid exchange"},
+	53:  {"EBADR", "Invalid request descriptor"},
+	54:  {"EXFULL", "Exchange full"},
+	55:  {"ENOANO", "No anode"},
+	56:  {"EBADRQC", "Invalid request code"},
+	57:  {"EBADSLT", "Invalid slot"},
+	59:  {"EBFONT", "Bad font file format"},
+	60:  {"ENOSTR", "Device not a stream"},
+	61:  {"ENODATA", "No data available"},
+	62:  {"ETIME", "Timer expired"},
+	63:  {"ENOSR", "Out of streams resources"},
+	64:  {"ENONET", "Machine is not on the network"},
+	65:  {"ENOPKG", "Package not installed"},
+	66:  {"EREMOTE", "Object is remote"},
+	67:  {"ENOLINK", "Link has been severed"},
+	68:  {"EADV", "Advertise error"},
+	69:  {"ESRMNT", "Srmount error"},
+	70:  {"ECOMM", "Communication error on send"},
+	71:  {"EPROTO", "Protocol error"},
+	72:  {"EMULTIHOP", "Multihop attempted"},
+	73:  {"EDOTDOT", "RFS specific error"},
+	74:  {"EBADMSG", "Not a data message"},
+	75:  {"EOVERFLOW", "Value too large for defined data type"},
+	76:  {"ENOTUNIQ", "Name not unique on network"},
+	77:  {"EBADFD", "File descriptor in bad state"},
+	78:  {"EREMCHG", "Remote address changed"},
+	79:  {"ELIBACC", "Can not access a needed shared library"},
+	80:  {"ELIBBAD", "Accessing a corrupted shared library"},
+	81:  {"ELIBSCN", ".lib section in a.out corrupted"},
+	82:  {"ELIBMAX", "Attempting to link in too many shared libraries"},
+	83:  {"ELIBEXEC", "Cannot exec a shared library directly"},
+	84:  {"EILSEQ", "Illegal byte sequence"},
+	85:  {"ERESTART", "Interrupted system call should be restarted"},
+	86:  {"ESTRPIPE", "Streams pipe error"},
+	87:  {"EUSERS", "Too many users"},
+	88:  {"ENOTSOCK", "Socket operation on non-socket"},
+	89:  {"EDESTADDRREQ", "Destination address required"},
+	90:  {"EMSGSIZE", "Message too long"},
+	91:  {"EPROTOTYPE", "Protocol wrong type for socket"},
+	92:  {"ENOPROTOOPT", "Protocol not available"},
+	93:  {"EPROTONOSUPPORT", "Protocol not supported"},
+	94:  {"ESOCKTNOSUPPORT", "Socket type not supported"},
+	95:  {"EOPNOTSUPP", "Operation not supported on transport endpoint"},
+	96:  {"EPFNOSUPPORT", "Protocol family not supported"},
+	97:  {"EAFNOSUPPORT", "Address family not supported by protocol"},
+	98:  {"EADDRINUSE", "Address already in use"},
+	99:  {"EADDRNOTAVAIL", "Cannot assign requested address"},
+	100: {"ENETDOWN", "Network is down"},
+	101: {"ENETUNREACH", "Network is unreachable"},
+	102: {"ENETRESET", "Network dropped connection because of reset"},
+	103: {"ECONNABORTED", "Software caused connection abort"},
+	104: {"ECONNRESET", "Connection reset by peer"},
+	105: {"ENOBUFS", "No buffer space available"},
+	106: {"EISCONN", "Transport endpoint is already connected"},
+	107: {"ENOTCONN", "Transport endpoint is not connected"},
+	108: {"ESHUTDOWN", "Cannot send after transport endpoint shutdown"},
+	109: {"ETOOMANYREFS", "Too many references: cannot splice"},
+	110: {"ETIMEDOUT", "Connection timed out"},
+	111: {"ECONNREFUSED", "Connection refused"},
+	112: {"EHOSTDOWN", "Host is down"},
+	113: {"EHOSTUNREACH", "No route to host"},
+	114: {"EALREADY", "Operation already in progress"},
+	115: {"EINPROGRESS", "Operation now in progress"},
+	116: {"ESTALE", "Stale file handle"},
+	117: {"EUCLEAN", "Structure needs cleaning"},
+	118: {"ENOTNAM", "Not a XENIX named type file"},
+	119: {"ENAVAIL", "No XENIX semaphores available"},
+	120: {"EISNAM", "Is a named type file"},
+	121: {"EREMOTEIO", "Remote I/O error"},
+	122: {"EDQUOT", "Quota exceeded"},
+	123: {"ENOMEDIUM", "No medium found"},
+	124: {"EMEDIUMTYPE", "Wrong medium type"},
+	125: {"ECANCELED", "Operation Canceled"},
+	126: {"ENOKEY", "Required key not available"},
+	127: {"EKEYEXPIRED", "Key has expired"},
+	128: {"EKEYREVOKED", "Key has been revoked"},
+	129: {"EKEYREJECTED", "Key was rejected by service"},
+	130: {"EOWNERDEAD", "Owner died"},
+	131: {"ENOTRECOVERABLE", "State not recoverable"},
+	132: {"ERFKILL", "Operation not possible due to RF-kill"},
+	133: {"EHWPOISON", "Memory page has hardware error"},
+}
+
+// errnoAliases are names that share the number of another one
+var errnoAliases = map[string]int{
+	"EWOULDBLOCK": 11,
+	"EDEADLOCK":   35,
+	"ENOTSUP":     95,
+}
+
+// signals are Linux's signal numbers (x86 and ARM)
+var signals = map[int]codeEntry{
+	1:  {"SIGHUP", "hangup"},
+	2:  {"SIGINT", "interrupt"},
+	3:  {"SIGQUIT", "quit"},
+	4:  {"SIGILL", "illegal instruction"},
+	5:  {"SIGTRAP", "trace/breakpoint trap"},
+	6:  {"SIGABRT", "aborted"},
+	7:  {"SIGBUS", "bus error"},
+	8:  {"SIGFPE", "floating point exception"},
+	9:  {"SIGKILL", "killed"},
+	10: {"SIGUSR1", "user defined signal 1"},
+	11: {"SIGSEGV", "segmentation fault"},
+	12: {"SIGUSR2", "user defined signal 2"},
+	13: {"SIGPIPE", "broken pipe"},
+	14: {"SIGALRM", "alarm clock"},
+	15: {"SIGTERM", "terminated"},
+	16: {"SIGSTKFLT", "stack fault"},
+	17: {"SIGCHLD", "child exited"},
+	18: {"SIGCONT", "continued"},
+	19: {"SIGSTOP", "stopped (signal)"},
+	20: {"SIGTSTP", "stopped"},
+	21: {"SIGTTIN", "stopped (tty input)"},
+	22: {"SIGTTOU", "stopped (tty output)"},
+	23: {"SIGURG", "urgent I/O condition"},
+	24: {"SIGXCPU", "CPU time limit exceeded"},
+	25: {"SIGXFSZ", "file size limit exceeded"},
+	26: {"SIGVTALRM", "virtual timer expired"},
+	27: {"SIGPROF", "profiling timer expired"},
+	28: {"SIGWINCH", "window changed"},
+	29: {"SIGIO", "I/O possible"},
+	30: {"SIGPWR", "power failure"},
+	31: {"SIGSYS", "bad system call"},
+}
+
+// signalAliases are names that share the number of another one
+var signalAliases = map[string]int{
+	"SIGIOT":  6,
+	"SIGPOLL": 29,
+	"SIGCLD":  17,
+}
+
+// exitCodes are the exit statuses shells and sysexits.h give a meaning to,
+// 128+N (killed by signal N) is worked out from `signals`
+var exitCodes = map[int]string{
+	0:   "success",
+	1:   "general error",
+	2:   "misuse of shell builtins",
+	64:  "command line usage error (EX_USAGE)",
+	65:  "data format error (EX_DATAERR)",
+	66:  "cannot open input (EX_NOINPUT)",
+	67:  "addressee unknown (EX_NOUSER)",
+	68:  "host name unknown (EX_NOHOST)",
+	69:  "service unavailable (EX_UNAVAILABLE)",
+	70:  "internal software error (EX_SOFTWARE)",
+	71:  "system error (EX_OSERR)",
+	72:  "critical OS file missing (EX_OSFILE)",
+	73:  "can't create output file (EX_CANTCREAT)",
+	74:  "input/output error (EX_IOERR)",
+	75:  "temporary failure, try again (EX_TEMPFAIL)",
+	76:  "remote error in protocol (EX_PROTOCOL)",
+	77:  "permission denied (EX_NOPERM)",
+	78:  "configuration error (EX_CONFIG)",
+	126: "command found but not executable",
+	127: "command not found",
+	128: "invalid argument to exit",
+	255: "exit status out of range",
+}
+
+// Code looks up the numbers that show up when things go wrong: HTTP status
+// codes, errno, signals and exit statuses. The machine side of things is the
+// number or the symbolic name programs print, the human side is what it
+// means, ie:
+// 	137          -> exit 137 = killed by SIGKILL (9)
+// 	ECONNREFUSED -> errno 111 = ECONNREFUSED (Connection refused)
+//
+// Going into the machine side of things names and descriptions are turned
+// back into numbers, ie:
+// 	SIGTERM             -> 15
+// 	service unavailable -> 503
+// 	killed by SIGKILL   -> 137
+//
+// A number can mean something in more than one table (ie: 2 is ENOENT, SIGINT
+// and an exit status) so all of them are written unless the parser is limited
+// to one kind of code
+type Code struct {
+	// kinds are the tables that are looked up, see `codeKinds`
+	kinds []string
+}
+
+// NewCode constructs a Code parser limited to one kind of code, an empty kind
+// means all of them
+func NewCode(kind string) (*Code, error) {
+	kind = strings.ToLower(kind)
+	if kind == "" {
+		return &Code{kinds: codeKinds}, nil
+	}
+	if !contains(codeKinds, kind) {
+		return nil, ErrUnknownCodeKind
+	}
+	return &Code{kinds: []string{kind}}, nil
+}
+
+// CanParseFromMachine determines if the input is a number or a symbolic name
+// in any of the tables
+func (c *Code) CanParseFromMachine(s string) (bool, error) {
+	if _, err := c.DoFromMachine(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CanParseIntoMachine determines if the input is a name or a description in
+// any of the tables
+func (c *Code) CanParseIntoMachine(s string) (bool, error) {
+	if _, err := c.DoIntoMachine(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DoFromMachine writes what the number means in each table it shows up in,
+// one per line. Symbolic names are explained along with their number
+func (c *Code) DoFromMachine(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	var lines []string
+	if regexp.MustCompile(`^[0-9]{1,3}$`).MatchString(s) {
+		n, _ := strconv.Atoi(s)
+		for _, kind := range c.kinds {
+			if line, ok := describeCode(kind, n); ok {
+				lines = append(lines, line)
+			}
+		}
+	} else if kind, n, ok := c.lookupName(s); ok {
+		line, _ := describeCode(kind, n)
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 {
+		return "", ErrNotACode
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// DoIntoMachine gives back the number of a symbolic name (ie: SIGTERM, TERM,
+// ENOENT), an HTTP reason phrase, an errno or signal description or an exit
+// status description (ie: command not found, killed by SIGKILL)
+func (c *Code) DoIntoMachine(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	if _, n, ok := c.lookupName(s); ok {
+		return strconv.Itoa(n), nil
+	}
+
+	if n, ok := c.lookupDescription(s); ok {
+		return strconv.Itoa(n), nil
+	}
+
+	return "", ErrNotACode
+}
+
+// Lossless is false, the same code can mean different things in different
+// tables
+func (c *Code) Lossless() bool {
+	return false
+}
+
+// lookupName finds the kind and number of a symbolic name, signals can be
+// written without the SIG prefix like `kill` takes them
+func (c *Code) lookupName(s string) (string, int, bool) {
+	name := strings.ToUpper(s)
+	for _, kind := range c.kinds {
+		switch kind {
+		case "errno":
+			if n, ok := findCodeName(errnos, errnoAliases, name); ok {
+				return kind, n, true
+			}
+		case "signal":
+			if !strings.HasPrefix(name, "SIG") {
+				name = "SIG" + name
+			}
+			if n, ok := findCodeName(signals, signalAliases, name); ok {
+				return kind, n, true
+			}
+		}
+	}
+	return "", 0, false
+}
+
+// lookupDescription finds the number of a description, ignoring case
+func (c *Code) lookupDescription(s string) (int, bool) {
+	for _, kind := range c.kinds {
+		for n := 0; n < 600; n++ {
+			line, ok := describeCode(kind, n)
+			if !ok {
+				continue
+			}
+
+			// Only what comes after the `=` is a description, without the
+			// names in parens
+			description := strings.SplitN(line, " = ", 2)[1]
+			if kind == "errno" || kind == "signal" {
+				description = codeTable(kind)[n].description
+			}
+			if strings.EqualFold(description, s) {
+				return n, true
+			}
+		}
+	}
+
+	// The killed by line has the signal number at the end which nobody writes
+	if match := regexp.MustCompile(`(?i)^killed by (\S+)$`).FindStringSubmatch(s); match != nil && contains(c.kinds, "exit") {
+		name := strings.ToUpper(match[1])
+		if !strings.HasPrefix(name, "SIG") {
+			name = "SIG" + name
+		}
+		if n, ok := findCodeName(signals, signalAliases, name); ok {
+			return 128 + n, true
+		}
+	}
+
+	return 0, false
+}
+
+// describeCode writes what the number means in the kind of table, ie:
+// errno 2 = ENOENT (No such file or directory)
+func describeCode(kind string, n int) (string, bool) {
+	switch kind {
+	case "http":
+		if text := http.StatusText(n); text != "" {
+			return fmt.Sprintf("HTTP %d = %s", n, text), true
+		}
+	case "errno", "signal":
+		if entry, ok := codeTable(kind)[n]; ok {
+			return fmt.Sprintf("%s %d = %s (%s)", kind, n, entry.name, entry.description), true
+		}
+	case "exit":
+		if description, ok := exitCodes[n]; ok {
+			return fmt.Sprintf("exit %d = %s", n, description), true
+		}
+		if signal, ok := signals[n-128]; ok {
+			return fmt.Sprintf("exit %d = killed by %s (%d)", n, signal.name, n-128), true
+		}
+		if n > 0 && n < 256 {
+			return fmt.Sprintf("exit %d = program specific error", n), true
+		}
+	}
+	return "", false
+}
+
+// codeTable gives back the table of symbolic names for the kind of code
+func codeTable(kind string) map[int]codeEntry {
+	if kind == "signal" {
+		return signals
+	}
+	return errnos
+}
+
+// findCodeName finds the number of the name in the table or its aliases
+func findCodeName(table map[int]codeEntry, aliases map[string]int, name string) (int, bool) {
+	for n, entry := range table {
+		if entry.name == name {
+			return n, true
+		}
+	}
+	n, ok := aliases[name]
+	return n, ok
+}
@@ -0,0 +1,81 @@
+package parsers
+
+import "testing"
+
+func TestCodeDoFromMachine(t *testing.T) {
+	tests := []struct {
+		kind string
+		in   string
+		out  string
+		err  error
+	}{
+		{"", "137", "exit 137 = killed by SIGKILL (9)", nil},
+		{"", "503", "HTTP 503 = Service Unavailable", nil},
+		{"", "2", "errno 2 = ENOENT (No such file or directory)\nsignal 2 = SIGINT (interrupt)\nexit 2 = misuse of shell builtins", nil},
+		{"", "127", "errno 127 = EKEYEXPIRED (Key has expired)\nexit 127 = command not found", nil},
+		{"", "ECONNREFUSED", "errno 111 = ECONNREFUSED (Connection refused)", nil},
+		{"", "EWOULDBLOCK", "errno 11 = EAGAIN (Try again)", nil},
+		{"", "sigterm", "signal 15 = SIGTERM (terminated)", nil},
+		{"signal", "2", "signal 2 = SIGINT (interrupt)", nil},
+		{"http", "137", "", ErrNotACode},
+		{"", "1000", "", ErrNotACode},
+		{"", "hello", "", ErrNotACode},
+	}
+
+	for i, tt := range tests {
+		code, _ := NewCode(tt.kind)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := code.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestCodeDoIntoMachine(t *testing.T) {
+	tests := []struct {
+		kind string
+		in   string
+		out  string
+		err  error
+	}{
+		{"", "ECONNREFUSED", "111", nil},
+		{"", "EDEADLOCK", "35", nil},
+		{"", "SIGKILL", "9", nil},
+		{"", "term", "15", nil},
+		{"", "Service Unavailable", "503", nil},
+		{"", "i'm a teapot", "418", nil},
+		{"", "connection refused", "111", nil},
+		{"", "command not found", "127", nil},
+		{"", "killed by SIGKILL", "137", nil},
+		{"", "killed by segv", "139", nil},
+		{"errno", "SIGKILL", "", ErrNotACode},
+		{"", "hello", "", ErrNotACode},
+	}
+
+	for i, tt := range tests {
+		code, _ := NewCode(tt.kind)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := code.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestNewCode(t *testing.T) {
+	if _, err := NewCode("HTTP"); err != nil {
+		t.Errorf("Given = `HTTP` ; want no error ; got `%v`", err)
+	}
+	if _, err := NewCode("windows"); err != ErrUnknownCodeKind {
+		t.Errorf("Given = `windows` ; want `%v` ; got `%v`", ErrUnknownCodeKind, err)
+	}
+}
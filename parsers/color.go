@@ -0,0 +1,675 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrNotAColor error = errors.New("Not a color, ie: #ff8800, rgb(255, 136, 0), hsl(32, 100%, 50%) or darkorange")
+var ErrUnknownColorNotation error = errors.New("Unknown color notation, use one of: hex, rgb, hsl, oklch, ansi, name")
+
+// colorNames are CSS's named colors
+var colorNames = map[string]int{
+	"aliceblue":            0xf0f8ff,
+	"antiquewhite":         0xfaebd7,
+	"aqua":                 0x00ffff,
+	"aquamarine":           0x7fffd4,
+	"azure":                0xf0ffff,
+	"beige":                0xf5f5dc,
+	"bisque":               0xffe4c4,
+	"black":                0x000000,
+	"blanchedalmond":       0xffebcd,
+	"blue":                 0x0000ff,
+	"blueviolet":           0x8a2be2,
+	"brown":                0xa52a2a,
+	"burlywood":            0xdeb887,
+	"cadetblue":            0x5f9ea0,
+	"chartreuse":           0x7fff00,
+	"chocolate":            0xd2691e,
+	"coral":                0xff7f50,
+	"cornflowerblue":       0x6495ed,
+	"cornsilk":             0xfff8dc,
+	"crimson":              0xdc143c,
+	"cyan":                 0x00ffff,
+	"darkblue":             0x00008b,
+	"darkcyan":             0x008b8b,
+	"darkgoldenrod":        0xb8860b,
+	"darkgray":             0xa9a9a9,
+	"darkgreen":            0x006400,
+	"darkgrey":             0xa9a9a9,
+	"darkkhaki":            0xbdb76b,
+	"darkmagenta":          0x8b008b,
+	"darkolivegreen":       0x556b2f,
+	"darkorange":           0xff8c00,
+	"darkorchid":           0x9932cc,
+	"darkred":              0x8b0000,
+	"darksalmon":           0xe9967a,
+	"darkseagreen":         0x8fbc8f,
+	"darkslateblue":        0x483d8b,
+	"darkslategray":        0x2f4f4f,
+	"darkslategrey":        0x2f4f4f,
+	"darkturquoise":        0x00ced1,
+	"darkviolet":           0x9400d3,
+	"deeppink":             0xff1493,
+	"deepskyblue":          0x00bfff,
+	"dimgray":              0x696969,
+	"dimgrey":              0x696969,
+	"dodgerblue":           0x1e90ff,
+	"firebrick":            0xb22222,
+	"floralwhite":          0xfffaf0,
+	"forestgreen":          0x228b22,
+	"fuchsia":              0xff00ff,
+	"gainsboro":            0xdcdcdc,
+	"ghostwhite":           0xf8f8ff,
+	"gold":                 0xffd700,
+	"goldenrod":            0xdaa520,
+	"gray":                 0x808080,
+	"green":                0x008000,
+	"greenyellow":          0xadff2f,
+	"grey":                 0x808080,
+	"honeydew":             0xf0fff0,
+	"hotpink":              0xff69b4,
+	"indianred":            0xcd5c5c,
+	"indigo":               0x4b0082,
+	"ivory":                0xfffff0,
+	"khaki":                0xf0e68c,
+	"lavender":             0xe6e6fa,
+	"lavenderblush":        0xfff0f5,
+	"lawngreen":            0x7cfc00,
+	"lemonchiffon":         0xfffacd,
+	"lightblue":            0xadd8e6,
+	"lightcoral":           0xf08080,
+	"lightcyan":            0xe0ffff,
+	"lightgoldenrodyellow": 0xfafad2,
+	"lightgray":            0xd3d3d3,
+	"lightgreen":           0x90ee90,
+	"lightgrey":            0xd3d3d3,
+	"lightpink":            0xffb6c1,
+	"lightsalmon":          0xffa07a,
+	"lightseagreen":        0x20b2aa,
+	"lightskyblue":         0x87cefa,
+	"lightslategray":       0x778899,
+	"lightslategrey":       0x778899,
+	"lightsteelblue":       0xb0c4de,
+	"lightyellow":          0xffffe0,
+	"lime":                 0x00ff00,
+	"limegreen":            0x32cd32,
+	"linen":                0xfaf0e6,
+	"magenta":              0xff00ff,
+	"maroon":               0x800000,
+	"mediumaquamarine":     0x66cdaa,
+	"mediumblue":           0x0000cd,
+	"mediumorchid":         0xba55d3,
+	"mediumpurple":         0x9370db,
+	"mediumseagreen":       0x3cb371,
+	"mediumslateblue":      0x7b68ee,
+	"mediumspringgreen":    0x00fa9a,
+	"mediumturquoise":      0x48d1cc,
+	"mediumvioletred":      0xc71585,
+	"midnightblue":         0x191970,
+	"mintcream":            0xf5fffa,
+	"mistyrose":            0xffe4e1,
+	"moccasin":             0xffe4b5,
+	"navajowhite":          0xffdead,
+	"navy":                 0x000080,
+	"oldlace":              0xfdf5e6,
+	"olive":                0x808000,
+	"olivedrab":            0x6b8e23,
+	"orange":               0xffa500,
+	"orangered":            0xff4500,
+	"orchid":               0xda70d6,
+	"palegoldenrod":        0xeee8aa,
+	"palegreen":            0x98fb98,
+	"paleturquoise":        0xafeeee,
+	"palevioletred":        0xdb7093,
+	"papayawhip":           0xffefd5,
+	"peachpuff":            0xffdab9,
+	"peru":                 0xcd853f,
+	"pink":                 0xffc0cb,
+	"plum":                 0xdda0dd,
+	"powderblue":           0xb0e0e6,
+	"purple":               0x800080,
+	"rebeccapurple":        0x663399,
+	"red":                  0xff0000,
+	"rosybrown":            0xbc8f8f,
+	"royalblue":            0x4169e1,
+	"saddlebrown":          0x8b4513,
+	"salmon":               0xfa8072,
+	"sandybrown":           0xf4a460,
+	"seagreen":             0x2e8b57,
+	"seashell":             0xfff5ee,
+	"sienna":               0xa0522d,
+	"silver":               0xc0c0c0,
+	"skyblue":              0x87ceeb,
+	"slateblue":            0x6a5acd,
+	"slategray":            0x708090,
+	"slategrey":            0x708090,
+	"snow":                 0xfffafa,
+	"springgreen":          0x00ff7f,
+	"steelblue":            0x4682b4,
+	"tan":                  0xd2b48c,
+	"teal":                 0x008080,
+	"thistle":              0xd8bfd8,
+	"tomato":               0xff6347,
+	"turquoise":            0x40e0d0,
+	"violet":               0xee82ee,
+	"wheat":                0xf5deb3,
+	"white":                0xffffff,
+	"whitesmoke":           0xf5f5f5,
+	"yellow":               0xffff00,
+	"yellowgreen":          0x9acd32,
+}
+
+// colorNotations are the ways a color can be written, in the order they're
+// shown
+var colorNotations = []string{"hex", "rgb", "hsl", "oklch", "ansi", "name"}
+
+// ansiCubeLevels are the values each channel takes in the 6x6x6 color cube of
+// the 256 color palette (16 to 231)
+var ansiCubeLevels = []int{0, 95, 135, 175, 215, 255}
+
+// ansiBasicColors are xterm's 16 basic colors, terminals let users change them
+// so they're only used when asked for by index
+var ansiBasicColors = []int{
+	0x000000, 0x800000, 0x008000, 0x808000, 0x000080, 0x800080, 0x008080, 0xc0c0c0,
+	0x808080, 0xff0000, 0x00ff00, 0xffff00, 0x0000ff, 0xff00ff, 0x00ffff, 0xffffff,
+}
+
+// rgbColor is a color in sRGB with each channel (and the alpha) between 0 and
+// 1
+type rgbColor struct {
+	r, g, b, a float64
+}
+
+// newRGB24 makes a color out of a 0xrrggbb number
+func newRGB24(n int) rgbColor {
+	return rgbColor{float64(n>>16&0xff) / 255, float64(n>>8&0xff) / 255, float64(n&0xff) / 255, 1}
+}
+
+// bytes gives back the channels between 0 and 255
+func (c rgbColor) bytes() (int, int, int) {
+	return int(math.Round(c.r * 255)), int(math.Round(c.g * 255)), int(math.Round(c.b * 255))
+}
+
+// rgb24 gives back the color as a 0xrrggbb number, the alpha is left out
+func (c rgbColor) rgb24() int {
+	r, g, b := c.bytes()
+	return r<<16 | g<<8 | b
+}
+
+// hsl gives back the hue in degrees and the saturation and lightness between
+// 0 and 1
+func (c rgbColor) hsl() (float64, float64, float64) {
+	max := math.Max(c.r, math.Max(c.g, c.b))
+	min := math.Min(c.r, math.Min(c.g, c.b))
+	l := (max + min) / 2
+	if max == min {
+		return 0, 0, l
+	}
+
+	d := max - min
+	s := d / (1 - math.Abs(2*l-1))
+	var h float64
+	switch max {
+	case c.r:
+		h = math.Mod((c.g-c.b)/d, 6)
+	case c.g:
+		h = (c.b-c.r)/d + 2
+	default:
+		h = (c.r-c.g)/d + 4
+	}
+	return math.Mod(h*60+360, 360), s, l
+}
+
+// fromHSL makes a color out of a hue in degrees and the saturation and
+// lightness between 0 and 1
+func fromHSL(h, s, l, a float64) rgbColor {
+	h = math.Mod(math.Mod(h, 360)+360, 360)
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return rgbColor{r + m, g + m, b + m, a}
+}
+
+// oklab gives back the color in Oklab, the space distances between colors are
+// measured in since they match what people see
+func (c rgbColor) oklab() (float64, float64, float64) {
+	r, g, b := srgbToLinear(c.r), srgbToLinear(c.g), srgbToLinear(c.b)
+
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		0.0259040371*l + 0.7827717662*m - 0.8086757660*s
+}
+
+// oklch gives back the lightness, chroma and hue (in degrees) in OKLCH
+func (c rgbColor) oklch() (float64, float64, float64) {
+	l, a, b := c.oklab()
+	h := math.Atan2(b, a) * 180 / math.Pi
+	return l, math.Hypot(a, b), math.Mod(h+360, 360)
+}
+
+// fromOklch makes a color out of OKLCH, colors that don't fit in sRGB are
+// clipped
+func fromOklch(l, ch, h, alpha float64) rgbColor {
+	a := ch * math.Cos(h*math.Pi/180)
+	b := ch * math.Sin(h*math.Pi/180)
+
+	lc := math.Pow(l+0.3963377774*a+0.2158037573*b, 3)
+	mc := math.Pow(l-0.1055613458*a-0.0638541728*b, 3)
+	sc := math.Pow(l-0.0894841775*a-1.2914855480*b, 3)
+
+	return rgbColor{
+		linearToSRGB(4.0767416621*lc - 3.3077115913*mc + 0.2309699292*sc),
+		linearToSRGB(-1.2684380046*lc + 2.6097574011*mc - 0.3413193965*sc),
+		linearToSRGB(-0.0041960863*lc - 0.7034186147*mc + 1.7076147010*sc),
+		alpha,
+	}
+}
+
+func srgbToLinear(c float64) float64 {
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(c float64) float64 {
+	if c <= 0.0031308 {
+		c *= 12.92
+	} else {
+		c = 1.055*math.Pow(c, 1/2.4) - 0.055
+	}
+	return math.Max(0, math.Min(1, c))
+}
+
+// distance is how different two colors look, measured in Oklab
+func (c rgbColor) distance(o rgbColor) float64 {
+	l1, a1, b1 := c.oklab()
+	l2, a2, b2 := o.oklab()
+	return math.Sqrt((l1-l2)*(l1-l2) + (a1-a2)*(a1-a2) + (b1-b2)*(b1-b2))
+}
+
+// luminance is the relative luminance WCAG uses to work out contrast
+func (c rgbColor) luminance() float64 {
+	return 0.2126*srgbToLinear(c.r) + 0.7152*srgbToLinear(c.g) + 0.0722*srgbToLinear(c.b)
+}
+
+// contrast is the WCAG contrast ratio between two colors, from 1 to 21
+func contrast(a, b rgbColor) float64 {
+	la, lb := a.luminance(), b.luminance()
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// ansiColor gives back the color of an index in the 256 color palette
+func ansiColor(i int) rgbColor {
+	switch {
+	case i < 16:
+		return newRGB24(ansiBasicColors[i])
+	case i < 232:
+		i -= 16
+		return newRGB24(ansiCubeLevels[i/36]<<16 | ansiCubeLevels[i/6%6]<<8 | ansiCubeLevels[i%6])
+	}
+	gray := 8 + (i-232)*10
+	return newRGB24(gray<<16 | gray<<8 | gray)
+}
+
+// nearestAnsi gives back the index in the 256 color palette that looks the
+// most like the color, the 16 basic colors are left out since terminals
+// change them
+func nearestAnsi(c rgbColor) int {
+	best := 16
+	for i := 16; i < 256; i++ {
+		if c.distance(ansiColor(i)) < c.distance(ansiColor(best)) {
+			best = i
+		}
+	}
+	return best
+}
+
+// nearestColorName gives back the CSS named color that looks the most like
+// the color, names for the same color (ie: gray and grey) are picked in
+// alphabetical order
+func nearestColorName(c rgbColor) string {
+	names := make([]string, 0, len(colorNames))
+	for name := range colorNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	best := names[0]
+	for _, name := range names {
+		if c.distance(newRGB24(colorNames[name])) < c.distance(newRGB24(colorNames[best])) {
+			best = name
+		}
+	}
+	return best
+}
+
+// Color converts colors between the ways CSS and terminals write them: hex,
+// rgb(), hsl(), oklch(), the 256 color palette and CSS named colors, ie:
+// 	#ff8c00 <-> darkorange
+// 	#ff8800 ->  rgb(255, 136, 0), hsl(32, 100%, 50%), ... nearest: darkorange
+//
+// Two colors (ie: #777 on #fff) are compared with the WCAG contrast ratio
+//
+// Going into the machine side of things colors are written in the parser's
+// notation, hex when there's none
+type Color struct {
+	// to is one of `colorNotations`
+	to string
+	// on is the background colors are compared against, nil means only
+	// compare colors that are given together
+	on *rgbColor
+	// swatch shows the color with truecolor escape codes, it only makes sense
+	// when writing to a terminal
+	swatch bool
+}
+
+// IsColorName determines if the word is one of CSS's named colors
+func IsColorName(s string) bool {
+	_, ok := colorNames[strings.ToLower(strings.TrimSpace(s))]
+	return ok
+}
+
+// NewColor constructs a Color parser, `on` is a color to compare against
+func NewColor(to, on string, swatch bool) (*Color, error) {
+	to = strings.ToLower(to)
+	if to == "" {
+		to = "hex"
+	}
+	if !contains(colorNotations, to) {
+		return nil, ErrUnknownColorNotation
+	}
+
+	c := &Color{to: to, swatch: swatch}
+	if on != "" {
+		bg, err := parseColor(on)
+		if err != nil {
+			return nil, err
+		}
+		c.on = &bg
+	}
+	return c, nil
+}
+
+// CanParseFromMachine determines if the input is a color or two colors to
+// compare
+func (c *Color) CanParseFromMachine(s string) (bool, error) {
+	if _, err := c.DoFromMachine(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CanParseIntoMachine determines if the input is a color
+func (c *Color) CanParseIntoMachine(s string) (bool, error) {
+	if _, err := parseColor(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DoFromMachine writes the color in every notation along with its name (or
+// the nearest one). Two colors get their contrast ratio instead
+func (c *Color) DoFromMachine(s string) (string, error) {
+	if fg, bg, ok := splitColors(s); ok {
+		return c.describeContrast(fg, bg), nil
+	}
+
+	color, err := parseColor(s)
+	if err != nil {
+		return "", err
+	}
+	if c.on != nil {
+		return c.describeContrast(color, *c.on), nil
+	}
+
+	lines := make([][2]string, 0, len(colorNotations))
+	for _, notation := range colorNotations[:4] {
+		lines = append(lines, [2]string{notation, writeColor(color, notation)})
+	}
+
+	ansi := strconv.Itoa(nearestAnsi(color))
+	if newRGB24(color.rgb24()).distance(ansiColor(nearestAnsi(color))) > 0.0001 {
+		ansi += " (nearest)"
+	}
+	lines = append(lines, [2]string{"ansi", ansi})
+
+	name := nearestColorName(color)
+	if colorNames[name] == color.rgb24() {
+		lines = append(lines, [2]string{"name", name})
+	} else {
+		lines = append(lines, [2]string{"nearest", fmt.Sprintf("%s (%s)", name, writeColor(newRGB24(colorNames[name]), "hex"))})
+	}
+
+	out := alignLines(lines)
+	if c.swatch {
+		r, g, b := color.bytes()
+		out = fmt.Sprintf("\x1b[48;2;%d;%d;%dm%s\x1b[0m\n", r, g, b, strings.Repeat(" ", 16)) + out
+	}
+	return out, nil
+}
+
+// DoIntoMachine writes the color in the parser's notation
+func (c *Color) DoIntoMachine(s string) (string, error) {
+	color, err := parseColor(s)
+	if err != nil {
+		return "", err
+	}
+	return writeColor(color, c.to), nil
+}
+
+// Lossless is false, colors are rounded when they're written in other
+// notations
+func (c *Color) Lossless() bool {
+	return false
+}
+
+// describeContrast writes the contrast ratio between the colors and which
+// WCAG levels it passes, for normal and large text
+func (c *Color) describeContrast(fg, bg rgbColor) string {
+	ratio := contrast(fg, bg)
+	level := func(normal, large float64) string {
+		return fmt.Sprintf("%s (normal text), %s (large text)", passFail(ratio >= normal), passFail(ratio >= large))
+	}
+
+	out := alignLines([][2]string{
+		{"contrast", strings.TrimSuffix(strings.TrimRight(strconv.FormatFloat(ratio, 'f', 2, 64), "0"), ".") + ":1"},
+		{"AA", level(4.5, 3)},
+		{"AAA", level(7, 4.5)},
+	})
+	if c.swatch {
+		fr, fgr, fb := fg.bytes()
+		br, bgr, bb := bg.bytes()
+		out = fmt.Sprintf("\x1b[38;2;%d;%d;%d;48;2;%d;%d;%dm Sample text \x1b[0m\n", fr, fgr, fb, br, bgr, bb) + out
+	}
+	return out
+}
+
+func passFail(ok bool) string {
+	if ok {
+		return "pass"
+	}
+	return "fail"
+}
+
+// splitColors reads two colors given together, ie: #777 on #fff, #777 #fff
+func splitColors(s string) (rgbColor, rgbColor, bool) {
+	s = strings.TrimSpace(s)
+	if m := regexp.MustCompile(`(?i)^(.+?)\s+(?:on|vs\.?|against)\s+(.+)$`).FindStringSubmatch(s); m != nil {
+		fg, ferr := parseColor(m[1])
+		bg, berr := parseColor(m[2])
+		return fg, bg, ferr == nil && berr == nil
+	}
+
+	// Notations like rgb() have spaces of their own so every space is tried
+	for i, r := range s {
+		if r != ' ' {
+			continue
+		}
+		fg, ferr := parseColor(s[:i])
+		bg, berr := parseColor(s[i+1:])
+		if ferr == nil && berr == nil {
+			return fg, bg, true
+		}
+	}
+	return rgbColor{}, rgbColor{}, false
+}
+
+// parseColor reads a color in any of the notations: #rgb, #rrggbb (with or
+// without alpha), rgb(), rgba(), hsl(), hsla(), oklch(), ansi(n) or a bare
+// palette index, and CSS names
+func parseColor(s string) (rgbColor, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if n, ok := colorNames[s]; ok {
+		return newRGB24(n), nil
+	}
+
+	if m := regexp.MustCompile(`^(?:#([0-9a-f]{3,4})|#?([0-9a-f]{6}|[0-9a-f]{8}))$`).FindStringSubmatch(s); m != nil {
+		digits := m[2]
+		for _, d := range m[1] {
+			digits += string(d) + string(d)
+		}
+		n, _ := strconv.ParseUint(digits, 16, 32)
+		if len(digits) == 8 {
+			c := newRGB24(int(n >> 8))
+			c.a = float64(n&0xff) / 255
+			return c, nil
+		}
+		return newRGB24(int(n)), nil
+	}
+
+	if m := regexp.MustCompile(`^(?:ansi\s*\(?\s*)?([0-9]{1,3})\)?$`).FindStringSubmatch(s); m != nil {
+		i, _ := strconv.Atoi(m[1])
+		if i > 255 {
+			return rgbColor{}, ErrNotAColor
+		}
+		return ansiColor(i), nil
+	}
+
+	m := regexp.MustCompile(`^(rgba?|hsla?|oklch)\((.*)\)$`).FindStringSubmatch(s)
+	if m == nil {
+		return rgbColor{}, ErrNotAColor
+	}
+
+	args := regexp.MustCompile(`[\s,/]+`).Split(strings.TrimSpace(m[2]), -1)
+	if len(args) != 3 && len(args) != 4 {
+		return rgbColor{}, ErrNotAColor
+	}
+
+	// Each argument is a number, maybe a percentage (of `full`) or an angle
+	values := make([]float64, 4)
+	values[3] = 1
+	for i, arg := range args {
+		full := map[string][]float64{
+			"rgb":   {255, 255, 255, 1},
+			"hsl":   {1, 1, 1, 1},
+			"oklch": {1, 0.4, 1, 1},
+		}[strings.TrimSuffix(m[1], "a")][i]
+
+		percent := strings.HasSuffix(arg, "%")
+		v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSuffix(arg, "%"), "deg"), 64)
+		if err != nil {
+			return rgbColor{}, ErrNotAColor
+		}
+		if percent {
+			v = v / 100 * full
+		}
+		values[i] = v
+	}
+
+	switch m[1] {
+	case "rgb", "rgba":
+		return rgbColor{clamp01(values[0] / 255), clamp01(values[1] / 255), clamp01(values[2] / 255), clamp01(values[3])}, nil
+	case "hsl", "hsla":
+		// Saturation and lightness are percentages, bare numbers included
+		s, l := values[1], values[2]
+		if !strings.HasSuffix(args[1], "%") {
+			s /= 100
+		}
+		if !strings.HasSuffix(args[2], "%") {
+			l /= 100
+		}
+		return fromHSL(values[0], clamp01(s), clamp01(l), clamp01(values[3])), nil
+	}
+	return fromOklch(values[0], values[1], values[2], clamp01(values[3])), nil
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// writeColor writes the color in the notation, the alpha is only written when
+// the color isn't opaque
+func writeColor(c rgbColor, notation string) string {
+	r, g, b := c.bytes()
+	alpha := c.a < 1
+	a := strconv.FormatFloat(math.Round(c.a*100)/100, 'f', -1, 64)
+
+	switch notation {
+	case "rgb":
+		if alpha {
+			return fmt.Sprintf("rgba(%d, %d, %d, %s)", r, g, b, a)
+		}
+		return fmt.Sprintf("rgb(%d, %d, %d)", r, g, b)
+	case "hsl":
+		h, s, l := c.hsl()
+		if alpha {
+			return fmt.Sprintf("hsla(%.0f, %.0f%%, %.0f%%, %s)", h, s*100, l*100, a)
+		}
+		return fmt.Sprintf("hsl(%.0f, %.0f%%, %.0f%%)", h, s*100, l*100)
+	case "oklch":
+		l, ch, h := c.oklch()
+		if ch < 0.0005 {
+			h = 0
+		}
+		out := fmt.Sprintf("oklch(%s %s %s", trimFloat(l, 3), trimFloat(ch, 3), trimFloat(h, 1))
+		if alpha {
+			out += " / " + a
+		}
+		return out + ")"
+	case "ansi":
+		return strconv.Itoa(nearestAnsi(c))
+	case "name":
+		return nearestColorName(c)
+	}
+
+	if alpha {
+		return fmt.Sprintf("#%06x%02x", c.rgb24(), int(math.Round(c.a*255)))
+	}
+	return fmt.Sprintf("#%06x", c.rgb24())
+}
+
+// trimFloat writes the number with up to `digits` decimals
+func trimFloat(v float64, digits int) string {
+	return strconv.FormatFloat(math.Round(v*math.Pow10(digits))/math.Pow10(digits), 'f', -1, 64)
+}
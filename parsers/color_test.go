@@ -0,0 +1,88 @@
+package parsers
+
+import "testing"
+
+func TestColorDoFromMachine(t *testing.T) {
+	orange := "hex:       #ff8800\nrgb:       rgb(255, 136, 0)\nhsl:       hsl(32, 100%, 50%)\noklch:     oklch(0.744 0.181 56.5)\nansi:      208 (nearest)\nnearest:   darkorange (#ff8c00)"
+
+	tests := []struct {
+		on  string
+		in  string
+		out string
+		err error
+	}{
+		{"", "#ff8800", orange, nil},
+		{"", "FF8800", orange, nil},
+		{"", "rgb(255,136,0)", orange, nil},
+		{"", "hsl(32deg, 100%, 50%)", orange, nil},
+		{"", "hsl(32 100 50)", orange, nil},
+		{"", "#f00", "hex:       #ff0000\nrgb:       rgb(255, 0, 0)\nhsl:       hsl(0, 100%, 50%)\noklch:     oklch(0.628 0.258 29.2)\nansi:      196\nname:      red", nil},
+		{"", "DarkOrange", "hex:       #ff8c00\nrgb:       rgb(255, 140, 0)\nhsl:       hsl(33, 100%, 50%)\noklch:     oklch(0.751 0.179 58.3)\nansi:      208 (nearest)\nname:      darkorange", nil},
+		{"", "rgb(100% 0% 0% / 100%)", "hex:       #ff0000\nrgb:       rgb(255, 0, 0)\nhsl:       hsl(0, 100%, 50%)\noklch:     oklch(0.628 0.258 29.2)\nansi:      196\nname:      red", nil},
+		{"", "oklch(0.628 0.258 29.2)", "hex:       #ff0000\nrgb:       rgb(255, 0, 0)\nhsl:       hsl(0, 100%, 50%)\noklch:     oklch(0.628 0.258 29.2)\nansi:      196\nname:      red", nil},
+		{"", "ansi(244)", "hex:       #808080\nrgb:       rgb(128, 128, 128)\nhsl:       hsl(0, 0%, 50%)\noklch:     oklch(0.6 0 0)\nansi:      244\nname:      gray", nil},
+		{"", "#ff880080", "hex:       #ff880080\nrgb:       rgba(255, 136, 0, 0.5)\nhsl:       hsla(32, 100%, 50%, 0.5)\noklch:     oklch(0.744 0.181 56.5 / 0.5)\nansi:      208 (nearest)\nnearest:   darkorange (#ff8c00)", nil},
+		// Contrast
+		{"", "black on white", "contrast:  21:1\nAA:        pass (normal text), pass (large text)\nAAA:       pass (normal text), pass (large text)", nil},
+		{"", "#777 #fff", "contrast:  4.48:1\nAA:        fail (normal text), pass (large text)\nAAA:       fail (normal text), fail (large text)", nil},
+		{"", "rgb(0, 0, 0) vs #767676", "contrast:  4.62:1\nAA:        pass (normal text), pass (large text)\nAAA:       fail (normal text), pass (large text)", nil},
+		{"#fff", "#767676", "contrast:  4.54:1\nAA:        pass (normal text), pass (large text)\nAAA:       fail (normal text), pass (large text)", nil},
+		// Not colors
+		{"", "256", "", ErrNotAColor},
+		{"", "rgb(1, 2)", "", ErrNotAColor},
+		{"", "hello", "", ErrNotAColor},
+	}
+
+	for i, tt := range tests {
+		color, _ := NewColor("", tt.on, false)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := color.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestColorDoIntoMachine(t *testing.T) {
+	tests := []struct {
+		to  string
+		in  string
+		out string
+		err error
+	}{
+		{"", "darkorange", "#ff8c00", nil},
+		{"rgb", "darkorange", "rgb(255, 140, 0)", nil},
+		{"hsl", "rebeccapurple", "hsl(270, 50%, 40%)", nil},
+		{"oklch", "white", "oklch(1 0 0)", nil},
+		{"ansi", "#ff8800", "208", nil},
+		{"name", "#ff8800", "darkorange", nil},
+		{"name", "#808080", "gray", nil},
+		{"", "hello", "", ErrNotAColor},
+	}
+
+	for i, tt := range tests {
+		color, _ := NewColor(tt.to, "", false)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := color.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestColorSwatch(t *testing.T) {
+	color, _ := NewColor("", "", true)
+	got, _ := color.DoFromMachine("#ff8800")
+	want := "\x1b[48;2;255;136;0m                \x1b[0m\nhex:       #ff8800"
+	if len(got) < len(want) || got[:len(want)] != want {
+		t.Errorf("Given = `#ff8800` ; want swatch `%q` ; got `%q`", want, got)
+	}
+}
@@ -0,0 +1,192 @@
+package parsers
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// compactStyles describes the suffixes used by each of the compact notations
+// along with the power of ten each suffix stands for.
+//
+// 	short: the "finance" style abbreviations (K, M, B, T)
+// 	si: the metric prefixes (k, M, G, T, P, E, Z, Y)
+var compactStyles = map[string][]struct {
+	suffix string
+	power  int
+}{
+	"short": {
+		{"K", 3},
+		{"M", 6},
+		{"B", 9},
+		{"T", 12},
+	},
+	"si": {
+		{"k", 3},
+		{"M", 6},
+		{"G", 9},
+		{"T", 12},
+		{"P", 15},
+		{"E", 18},
+		{"Z", 21},
+		{"Y", 24},
+	},
+}
+
+// NumberCompact handles strings made up of contiguous "0-9" characters and
+// converts to and from the abbreviated notation, ie: 1200 <-> 1.2K
+type NumberCompact struct {
+	// style is one of the keys in `compactStyles`
+	style string
+	// precision is the maximum amount of decimal places shown, trailing zeros
+	// are always dropped so `1000000` is `1M` and not `1.0M`
+	precision int
+	// exact ignores the precision and shows as many decimals as it takes, ie:
+	// 1234567 -> 1.234567M
+	exact bool
+}
+
+// NewNumberCompact constructs a NumberCompact parser
+// The style can be "short" or "si" and defaults to "short" if anything unknown
+// is passed. Negative precisions are treated as 0
+func NewNumberCompact(style string, precision int) *NumberCompact {
+	if _, ok := compactStyles[style]; !ok {
+		style = "short"
+	}
+
+	if precision < 0 {
+		precision = 0
+	}
+
+	return &NumberCompact{style: style, precision: precision}
+}
+
+// SetExact makes the output read back into the same number, the precision is
+// ignored and every decimal is shown
+func (n *NumberCompact) SetExact(exact bool) {
+	n.exact = exact
+}
+
+// CanParseFromMachine determines if input is within bounds
+// in that the input:
+// 	Contains only digits
+// 	Is >= 1000
+func (n *NumberCompact) CanParseFromMachine(s string) (bool, error) {
+	if isMachineNumber(s) && len(s) >= 4 {
+		return true, nil
+	}
+
+	if isMachineNumber(s) {
+		return false, ErrTooSmall
+	}
+	return false, ErrNotANumber
+}
+
+// CanParseIntoMachine determines if the input is a number followed by one of
+// the suffixes of the style being used, ie:
+// 	<digits>[.<digits>]<suffix>
+func (n *NumberCompact) CanParseIntoMachine(s string) (bool, error) {
+	_, _, err := n.getInputComponents(s)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DoFromMachine picks the largest suffix that keeps the number at or above 1
+// and rounds the rest to the configured precision
+func (n *NumberCompact) DoFromMachine(s string) (string, error) {
+	num, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return "", ErrNotANumber
+	}
+
+	units := compactStyles[n.style]
+	idx := -1
+	for i, u := range units {
+		if num.Cmp(pow10Rat(u.power)) >= 0 {
+			idx = i
+		}
+	}
+
+	if idx < 0 {
+		return s, nil
+	}
+
+	if n.exact {
+		return n.render(num, units[idx].power, units[idx].power) + units[idx].suffix, nil
+	}
+
+	// Rounding can push the value into the next unit, ie: 999999 would come out
+	// as 1000K which isn't what a human would write
+	rendered := n.render(num, units[idx].power, n.precision)
+	if idx+1 < len(units) && rendered == "1000" {
+		idx++
+		rendered = n.render(num, units[idx].power, n.precision)
+	}
+
+	return rendered + units[idx].suffix, nil
+}
+
+// DoIntoMachine multiplies the number by the power of the suffix, rounding to
+// the nearest whole number when the input has more decimals than the suffix
+// can absorb (ie: 1.2345K)
+func (n *NumberCompact) DoIntoMachine(s string) (string, error) {
+	num, power, err := n.getInputComponents(s)
+	if err != nil {
+		return "", err
+	}
+
+	res := new(big.Rat).Mul(num, pow10Rat(power))
+	return res.FloatString(0), nil
+}
+
+// Lossless is only true in exact mode, otherwise the number is rounded to
+// the precision
+func (n *NumberCompact) Lossless() bool {
+	return n.exact
+}
+
+// render divides the number by 10^power and rounds it to the precision,
+// dropping any trailing zeros
+func (n *NumberCompact) render(num *big.Rat, power, precision int) string {
+	res := new(big.Rat).Quo(num, pow10Rat(power)).FloatString(precision)
+	if strings.Contains(res, ".") {
+		res = strings.TrimRight(strings.TrimRight(res, "0"), ".")
+	}
+	return res
+}
+
+// getInputComponents splits the input into the number and the power of ten
+// that the suffix stands for
+func (n *NumberCompact) getInputComponents(s string) (*big.Rat, int, error) {
+	r := regexp.MustCompile(`^([0-9]+(\.[0-9]+)?)([a-zA-Z])$`)
+	match := r.FindStringSubmatch(strings.TrimSpace(s))
+	if len(match) != 4 {
+		return nil, 0, ErrUnparsable
+	}
+
+	num, ok := new(big.Rat).SetString(match[1])
+	if !ok {
+		return nil, 0, ErrNotANumber
+	}
+
+	// The short style has no overlapping letters so we can be lenient with the
+	// case, the SI prefixes however are case sensitive (m is milli, M is mega)
+	// so there we only forgive the very common `K`
+	suffix := match[3]
+	for _, u := range compactStyles[n.style] {
+		if suffix == u.suffix ||
+			(n.style == "short" && strings.EqualFold(suffix, u.suffix)) ||
+			(n.style == "si" && suffix == "K" && u.suffix == "k") {
+			return num, u.power, nil
+		}
+	}
+
+	return nil, 0, ErrUnknownSuffix
+}
+
+// pow10Rat gives back 10^power as a rational number
+func pow10Rat(power int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(power)), nil))
+}
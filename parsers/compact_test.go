@@ -0,0 +1,143 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestNumberCompactCanParseFromMachine(t *testing.T) {
+	tests := []struct {
+		in  string
+		out bool
+		err error
+	}{
+		{"aba", false, ErrNotANumber},
+		{"12af", false, ErrNotANumber},
+		{"1.2K", false, ErrNotANumber},
+		{"999", false, ErrTooSmall},
+		{"1000", true, nil},
+		{"1338054622987", true, nil},
+	}
+
+	compact := NewNumberCompact("short", 1)
+	for i, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := compact.CanParseFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestNumberCompactCanParseIntoMachine(t *testing.T) {
+	tests := []struct {
+		style string
+		in    string
+		out   bool
+		err   error
+	}{
+		{"short", "1.2K", true, nil},
+		{"short", "1.2k", true, nil},
+		{"short", "5B", true, nil},
+		{"short", "5G", false, ErrUnknownSuffix},
+		{"short", "1.2.3K", false, ErrUnparsable},
+		{"short", "K", false, ErrUnparsable},
+		{"short", "1000", false, ErrUnparsable},
+		{"si", "5G", true, nil},
+		{"si", "3k", true, nil},
+		{"si", "3K", true, nil},
+		// m is milli, not mega
+		{"si", "3m", false, ErrUnknownSuffix},
+		{"si", "5B", false, ErrUnknownSuffix},
+	}
+
+	for i, tt := range tests {
+		compact := NewNumberCompact(tt.style, 1)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := compact.CanParseIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestNumberCompactDoFromMachine(t *testing.T) {
+	tests := []struct {
+		style     string
+		precision int
+		in        string
+		out       string
+		err       error
+	}{
+		{"short", 1, "1000", "1K", nil},
+		{"short", 1, "1200", "1.2K", nil},
+		{"short", 1, "1250", "1.3K", nil},
+		{"short", 1, "1000000", "1M", nil},
+		{"short", 1, "3400000", "3.4M", nil},
+		{"short", 1, "5000000000", "5B", nil},
+		{"short", 1, "7100000000000", "7.1T", nil},
+		// Past the last suffix the number keeps growing
+		{"short", 1, "7100000000000000", "7100T", nil},
+		// Rounding should roll over into the next suffix
+		{"short", 1, "999999", "1M", nil},
+		{"short", 2, "999999", "1M", nil},
+		{"short", 0, "1500", "2K", nil},
+		{"short", 2, "1234", "1.23K", nil},
+		{"short", 3, "1234", "1.234K", nil},
+		{"si", 1, "1200", "1.2k", nil},
+		{"si", 1, "5000000000", "5G", nil},
+		{"si", 1, "2000000000000000000000000", "2Y", nil},
+	}
+
+	for i, tt := range tests {
+		compact := NewNumberCompact(tt.style, tt.precision)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := compact.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestNumberCompactDoIntoMachine(t *testing.T) {
+	tests := []struct {
+		style string
+		in    string
+		out   string
+		err   error
+	}{
+		{"short", "1K", "1000", nil},
+		{"short", "1.2K", "1200", nil},
+		{"short", "3.4M", "3400000", nil},
+		{"short", "5B", "5000000000", nil},
+		{"short", "1.2345K", "1235", nil},
+		{"short", "5x", "", ErrUnknownSuffix},
+		{"si", "5G", "5000000000", nil},
+		{"si", "1.5k", "1500", nil},
+		{"si", "2Y", "2000000000000000000000000", nil},
+	}
+
+	for i, tt := range tests {
+		compact := NewNumberCompact(tt.style, 1)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := compact.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
@@ -428,10 +428,20 @@ func (c *Cron) CanParseFromMachine(input string) (bool, error) {
 	return true, nil
 }
 
+// CanParseIntoMachine is always false for now, see DoIntoMachine
+func (c *Cron) CanParseIntoMachine(string) (bool, error) {
+	return false, ErrNotYetImplemented
+}
+
 func (c *Cron) DoIntoMachine(string) (string, error) {
 	return ErrNotYetImplemented.Error(), nil
 }
 
+// Lossless is false, schedules are only described
+func (c *Cron) Lossless() bool {
+	return false
+}
+
 func (c *Cron) DoFromMachine(input string) (string, error) {
 	parsed, err := c.parseInputOrError(input)
 	if err != nil {
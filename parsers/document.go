@@ -0,0 +1,342 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrNotADocument error = errors.New("Not a JSON or YAML document")
+var ErrBadPath error = errors.New("Bad JSONPath")
+
+// Document is a JSON or YAML document whose values can be rewritten, it's
+// written back in the syntax it was read in and with its keys in the same
+// order
+type Document struct {
+	text string
+	json bool
+	// notes are the values to write instead of the ones in the document, by
+	// their JSONPath
+	notes map[string]string
+}
+
+// ParseDocument reads a JSON or YAML document, JSON being the one that
+// starts with { or [
+func ParseDocument(text string) (*Document, error) {
+	d := &Document{
+		text:  text,
+		json:  regexp.MustCompile(`^\s*[{[]`).MatchString(text),
+		notes: map[string]string{},
+	}
+
+	root, err := d.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	// A lone scalar is a document as far as YAML is concerned, but there's
+	// nothing to walk through in it
+	if len(root.Content) == 0 || root.Content[0].Kind == yaml.ScalarNode {
+		return nil, ErrNotADocument
+	}
+	return d, nil
+}
+
+// parse reads the text again each time, that way writing the document out
+// doesn't change it
+func (d *Document) parse() (*yaml.Node, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(d.text), &root); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotADocument, err)
+	}
+	return &root, nil
+}
+
+// IsJSON tells if the document was written in JSON
+func (d *Document) IsJSON() bool {
+	return d.json
+}
+
+// Rewrite calls fn for every value in the document (ie: not objects nor
+// lists) with its JSONPath, the key it's under (the closest one for the
+// values in a list) and the value. When fn gives back true the value is
+// replaced by the one it gave back
+func (d *Document) Rewrite(fn func(path, key, value string) (string, bool)) {
+	root, _ := d.parse()
+	walkDocument(root.Content[0], "$", "", func(node *yaml.Node, path, key string) {
+		if node.Kind != yaml.ScalarNode || node.Tag == "!!null" {
+			return
+		}
+		if out, ok := fn(path, key, node.Value); ok {
+			d.notes[path] = out
+		}
+	})
+}
+
+// String writes the document with the rewritten values in place
+func (d *Document) String() string {
+	return d.render(false)
+}
+
+// Annotated writes the document as it is with the rewritten values next to
+// the original ones, as comments
+func (d *Document) Annotated() string {
+	return d.render(true)
+}
+
+func (d *Document) render(annotate bool) string {
+	root, _ := d.parse()
+
+	if d.json {
+		var lines []documentLine
+		d.writeJSON(&lines, root.Content[0], "$", "", "", annotate)
+		return alignNotes(lines, "//")
+	}
+
+	walkDocument(root.Content[0], "$", "", func(node *yaml.Node, path, key string) {
+		note, ok := d.notes[path]
+		if !ok {
+			return
+		}
+		if annotate {
+			node.LineComment = note
+			return
+		}
+		node.Value, node.Tag, node.Style = note, "!!str", 0
+	})
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	enc.Encode(root)
+	enc.Close()
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// documentLine is a line of the document along with what goes next to it in
+// the annotated view
+type documentLine struct {
+	text string
+	note string
+}
+
+// writeJSON writes the node with 2 spaces of indentation, prefix goes before
+// it on its first line (ie: the key)
+func (d *Document) writeJSON(lines *[]documentLine, node *yaml.Node, path, indent, prefix string, annotate bool) {
+	switch node.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		open, close := "{", "}"
+		if node.Kind == yaml.SequenceNode {
+			open, close = "[", "]"
+		}
+		if len(node.Content) == 0 {
+			*lines = append(*lines, documentLine{text: indent + prefix + open + close})
+			break
+		}
+
+		*lines = append(*lines, documentLine{text: indent + prefix + open})
+		forEachChild(node, path, func(child *yaml.Node, childPath, key string) {
+			childPrefix := ""
+			if node.Kind == yaml.MappingNode {
+				childPrefix = jsonString(key) + ": "
+			}
+			d.writeJSON(lines, child, childPath, indent+"  ", childPrefix, annotate)
+			(*lines)[len(*lines)-1].text += ","
+		})
+		last := &(*lines)[len(*lines)-1]
+		last.text = strings.TrimSuffix(last.text, ",")
+		*lines = append(*lines, documentLine{text: indent + close})
+
+	case yaml.AliasNode:
+		d.writeJSON(lines, node.Alias, path, indent, prefix, annotate)
+
+	default:
+		value := node.Value
+		switch {
+		case node.Tag == "!!null":
+			value = "null"
+		case node.Tag == "!!str" || node.Tag == "!!timestamp" || node.Tag == "!!binary":
+			value = jsonString(value)
+		}
+
+		note, ok := d.notes[path]
+		if ok && !annotate {
+			value, note = jsonString(note), ""
+		}
+		*lines = append(*lines, documentLine{text: indent + prefix + value, note: note})
+	}
+}
+
+// walkDocument calls fn for the node and everything in it, depth first
+func walkDocument(node *yaml.Node, path, key string, fn func(node *yaml.Node, path, key string)) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	fn(node, path, key)
+	forEachChild(node, path, func(child *yaml.Node, childPath, childKey string) {
+		if childKey == "" {
+			childKey = key
+		}
+		walkDocument(child, childPath, childKey, fn)
+	})
+}
+
+// forEachChild calls fn for the values in an object (with their keys) or a
+// list (with no key)
+func forEachChild(node *yaml.Node, path string, fn func(child *yaml.Node, childPath, key string)) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			fn(node.Content[i+1], path+jsonPathKey(key), key)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			fn(child, path+"["+strconv.Itoa(i)+"]", "")
+		}
+	}
+}
+
+// jsonPathKey writes the key the way it goes in a JSONPath, ie: .name or
+// ['odd name']
+func jsonPathKey(key string) string {
+	if regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`).MatchString(key) {
+		return "." + key
+	}
+	return "['" + strings.ReplaceAll(key, "'", `\'`) + "']"
+}
+
+// jsonString writes the string as a JSON string, leaving <, > and & alone
+func jsonString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// alignNotes writes the lines with their notes lined up in a column to the
+// right of the longest line that has one
+func alignNotes(lines []documentLine, comment string) string {
+	width := 0
+	for _, l := range lines {
+		if n := utf8.RuneCountInString(l.text); l.note != "" && n > width {
+			width = n
+		}
+	}
+
+	var out []string
+	for _, l := range lines {
+		if l.note == "" {
+			out = append(out, l.text)
+			continue
+		}
+		pad := strings.Repeat(" ", width-utf8.RuneCountInString(l.text))
+		out = append(out, l.text+pad+"  "+comment+" "+l.note)
+	}
+	return strings.Join(out, "\n")
+}
+
+// pathToken is a step in a JSONPath, either a key or an index
+type pathToken struct {
+	name     string
+	index    int
+	wildcard bool
+	// recursive is for `..`, the step can be at any depth below the previous
+	// one
+	recursive bool
+}
+
+// parseJSONPath splits the path into its steps, the subset of JSONPath that's
+// understood is:
+// 	$.key, $['key']  a key
+// 	$[0]             an index
+// 	$.*, $[*]        any key or index
+// 	$..key           the key at any depth
+func parseJSONPath(p string) ([]pathToken, error) {
+	if !strings.HasPrefix(p, "$") {
+		return nil, fmt.Errorf("%w: '%s'", ErrBadPath, p)
+	}
+
+	step := regexp.MustCompile(`^(\.\.|\.)?(?:([A-Za-z_][A-Za-z0-9_-]*)|(\*)|\[(?:([0-9]+)|(\*)|'((?:[^'\\]|\\.)*)'|"((?:[^"\\]|\\.)*)")\])`)
+	var tokens []pathToken
+	rest := p[1:]
+	for rest != "" {
+		m := step.FindStringSubmatch(rest)
+		// Keys have to come after a dot and a dot has to have something after it
+		if m == nil || (m[1] == "" && (m[2] != "" || m[3] != "")) {
+			return nil, fmt.Errorf("%w: '%s'", ErrBadPath, p)
+		}
+		rest = rest[len(m[0]):]
+
+		t := pathToken{index: -1, recursive: m[1] == ".."}
+		switch {
+		case m[2] != "":
+			t.name = m[2]
+		case m[3] != "" || m[5] != "":
+			t.wildcard = true
+		case m[4] != "":
+			t.index, _ = strconv.Atoi(m[4])
+		default:
+			t.name = strings.ReplaceAll(m[6]+m[7], `\`, "")
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, nil
+}
+
+// MatchDocumentPath tells if a value matches the pattern, which is either a
+// JSONPath (starting with $) or a pattern for the key, ie: *_bytes
+func MatchDocumentPath(pattern, p, key string) (bool, error) {
+	if !strings.HasPrefix(pattern, "$") {
+		matched, err := path.Match(pattern, key)
+		if err != nil {
+			return false, fmt.Errorf("%w: '%s'", ErrBadPath, pattern)
+		}
+		return matched, nil
+	}
+
+	want, err := parseJSONPath(pattern)
+	if err != nil {
+		return false, err
+	}
+	have, err := parseJSONPath(p)
+	if err != nil {
+		return false, err
+	}
+	return matchPathTokens(want, have), nil
+}
+
+func matchPathTokens(want, have []pathToken) bool {
+	if len(want) == 0 {
+		return len(have) == 0
+	}
+
+	step := func(w, h pathToken) bool {
+		switch {
+		case w.wildcard:
+			return true
+		case w.index >= 0:
+			return h.index == w.index
+		}
+		return h.index < 0 && h.name == w.name
+	}
+
+	if !want[0].recursive {
+		return len(have) > 0 && step(want[0], have[0]) && matchPathTokens(want[1:], have[1:])
+	}
+	for i := range have {
+		if step(want[0], have[i]) && matchPathTokens(want[1:], have[i+1:]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package parsers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// upper rewrites the values under keys ending in _name
+func upper(path, key, value string) (string, bool) {
+	if strings.HasSuffix(key, "_name") {
+		return strings.ToUpper(value), true
+	}
+	return "", false
+}
+
+func TestDocumentRewrite(t *testing.T) {
+	tests := []struct {
+		in        string
+		out       string
+		annotated string
+	}{
+		{
+			`{"first_name": "ada", "age": 36, "tags": ["x"], "nick_name": null, "ok": true}`,
+			"{\n  \"first_name\": \"ADA\",\n  \"age\": 36,\n  \"tags\": [\n    \"x\"\n  ],\n  \"nick_name\": null,\n  \"ok\": true\n}",
+			"{\n  \"first_name\": \"ada\",  // ADA\n  \"age\": 36,\n  \"tags\": [\n    \"x\"\n  ],\n  \"nick_name\": null,\n  \"ok\": true\n}",
+		},
+		{
+			`[{"a_name": "x <y>"}, {"b_name": "z", "c": {}}]`,
+			"[\n  {\n    \"a_name\": \"X <Y>\"\n  },\n  {\n    \"b_name\": \"Z\",\n    \"c\": {}\n  }\n]",
+			"[\n  {\n    \"a_name\": \"x <y>\"  // X <Y>\n  },\n  {\n    \"b_name\": \"z\",     // Z\n    \"c\": {}\n  }\n]",
+		},
+		{
+			"# people\nfirst_name: ada\nlast_names:\n  - lovelace\n  - byron\nage: 36",
+			"# people\nfirst_name: ADA\nlast_names:\n  - lovelace\n  - byron\nage: 36",
+			"# people\nfirst_name: ada # ADA\nlast_names:\n  - lovelace\n  - byron\nage: 36",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			doc, err := ParseDocument(tt.in)
+			if err != nil {
+				t.Fatalf("Error Case %d: Given = `%s` ; got `%v`", i, tt.in, err)
+			}
+			doc.Rewrite(upper)
+			if got := doc.String(); got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if got := doc.Annotated(); got != tt.annotated {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.annotated, got)
+			}
+		})
+	}
+}
+
+func TestDocumentRewritePaths(t *testing.T) {
+	doc, _ := ParseDocument(`{"a": {"b c": [1, {"d": 2}]}, "e": 3}`)
+
+	var got []string
+	doc.Rewrite(func(path, key, value string) (string, bool) {
+		got = append(got, path+" "+key+" "+value)
+		return "", false
+	})
+
+	want := []string{"$.a['b c'][0] b c 1", "$.a['b c'][1].d d 2", "$.e e 3"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("want `%v` ; got `%v`", want, got)
+	}
+}
+
+func TestParseDocument(t *testing.T) {
+	for i, in := range []string{"hello", "42", "", `{"a": `} {
+		if _, err := ParseDocument(in); !errors.Is(err, ErrNotADocument) {
+			t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, in, ErrNotADocument, err)
+		}
+	}
+}
+
+func TestMatchDocumentPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		key     string
+		out     bool
+		err     error
+	}{
+		{"*_bytes", "$.disk.used_bytes", "used_bytes", true, nil},
+		{"*_bytes", "$.disk.used", "used", false, nil},
+		{"schedule", "$.jobs[0].schedule", "schedule", true, nil},
+		{"$.jobs[*].schedule", "$.jobs[3].schedule", "schedule", true, nil},
+		{"$.jobs[1].schedule", "$.jobs[3].schedule", "schedule", false, nil},
+		{"$.jobs.*.schedule", "$.jobs.nightly.schedule", "schedule", true, nil},
+		{"$..schedule", "$.a.b[2].schedule", "schedule", true, nil},
+		{"$..b[*].schedule", "$.a.b[2].schedule", "schedule", true, nil},
+		{"$.schedule", "$.a.schedule", "schedule", false, nil},
+		{"$['odd key']", "$['odd key']", "odd key", true, nil},
+		{"$.a", "$.a.b", "b", false, nil},
+		{"$a", "$.a", "a", false, ErrBadPath},
+		{"$.", "$.a", "a", false, ErrBadPath},
+		{"[", "$.a", "a", false, ErrBadPath},
+	}
+
+	for i, tt := range tests {
+		got, err := MatchDocumentPath(tt.pattern, tt.path, tt.key)
+		if got != tt.out {
+			t.Errorf("Case %d: Given = `%s` `%s` ; want `%v` ; got `%v`", i, tt.pattern, tt.path, tt.out, got)
+		}
+		if !errors.Is(err, tt.err) {
+			t.Errorf("Error Case %d: Given = `%s` `%s` ; want `%v` ; got `%v`", i, tt.pattern, tt.path, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,468 @@
+package parsers
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNotADuration error = errors.New("Not a duration, ie: 2h 3m, P1DT2H or an hour and a half")
+
+// durationUnits are ordered from largest to smallest so that they can be
+// consumed greedily when humanizing
+var durationUnits = []struct {
+	symbol string
+	name   string
+	size   time.Duration
+}{
+	{"d", "day", 24 * time.Hour},
+	{"h", "hour", time.Hour},
+	{"m", "minute", time.Minute},
+	{"s", "second", time.Second},
+	{"ms", "millisecond", time.Millisecond},
+	{"µs", "microsecond", time.Microsecond},
+	{"ns", "nanosecond", time.Nanosecond},
+}
+
+// durationMachineUnits are the units a machine number can be in
+var durationMachineUnits = map[string]time.Duration{
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ns": time.Nanosecond,
+}
+
+// durationWords are the names (and common abbreviations) understood when
+// reading a duration written in English
+var durationWords = map[string]time.Duration{
+	"nanosecond": time.Nanosecond, "nanoseconds": time.Nanosecond,
+	"microsecond": time.Microsecond, "microseconds": time.Microsecond,
+	"millisecond": time.Millisecond, "milliseconds": time.Millisecond,
+	"second": time.Second, "seconds": time.Second, "sec": time.Second, "secs": time.Second,
+	"minute": time.Minute, "minutes": time.Minute, "min": time.Minute, "mins": time.Minute,
+	"hour": time.Hour, "hours": time.Hour, "hr": time.Hour, "hrs": time.Hour,
+	"day": 24 * time.Hour, "days": 24 * time.Hour,
+	"week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+// durationFractions are the fractions that can be used in English, ie: "an
+// hour and a half" or "three quarters of an hour"
+var durationFractions = map[string]float64{
+	"half": 0.5, "halves": 0.5,
+	"quarter": 0.25, "quarters": 0.25,
+	"third": 1.0 / 3, "thirds": 1.0 / 3,
+}
+
+// durationFillers are words that can be dropped since they don't change the
+// value, this way our own approximate output can be read back
+var durationFillers = []string{"about", "approximately", "roughly", "around", "nearly", "almost"}
+
+// Duration converts a number of seconds (or milliseconds, nanoseconds...) into
+// something a human can read, ie: 7384 -> 2h 3m 4s
+//
+// The styles of output are:
+// 	short   2h 3m 4s
+// 	long    2 hours, 3 minutes, 4 seconds
+// 	approx  about 2 hours
+//
+// Going into the machine side of things, any of the following is understood:
+// 	1h30m, 2h 3m 4s, 1d 2h    Go's `time.ParseDuration` (plus days and weeks)
+// 	P1DT2H, PT90M             ISO-8601 durations
+// 	an hour and a half        English phrases
+//
+// ISO-8601 years and months are nominal, a year is 365 days and a month is 30
+type Duration struct {
+	// unit is what the machine number is counting, one of the keys in
+	// `durationMachineUnits`
+	unit string
+	// style is how the output is written: short, long or approx
+	style string
+}
+
+// NewDuration constructs a Duration parser, the unit defaults to seconds and
+// the style to short if anything unknown is passed
+func NewDuration(unit, style string) *Duration {
+	if _, ok := durationMachineUnits[unit]; !ok {
+		unit = "s"
+	}
+
+	if style != "long" && style != "approx" {
+		style = "short"
+	}
+
+	return &Duration{unit: unit, style: style}
+}
+
+// CanParseFromMachine determines if the input is a whole number that fits in
+// a duration
+func (d *Duration) CanParseFromMachine(s string) (bool, error) {
+	if _, err := d.fromMachine(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CanParseIntoMachine determines if the input is written in any of the
+// notations we understand
+func (d *Duration) CanParseIntoMachine(s string) (bool, error) {
+	if _, err := parseDuration(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DoFromMachine writes the duration in the parser's style
+func (d *Duration) DoFromMachine(s string) (string, error) {
+	dur, err := d.fromMachine(s)
+	if err != nil {
+		return "", err
+	}
+
+	switch d.style {
+	case "long":
+		return humanizeDurationLong(dur), nil
+	case "approx":
+		return humanizeDurationApprox(dur), nil
+	default:
+		return humanizeDurationShort(dur), nil
+	}
+}
+
+// DoIntoMachine gives back the duration counted in the parser's unit, which
+// can have decimals when the duration isn't a whole amount of the unit
+func (d *Duration) DoIntoMachine(s string) (string, error) {
+	dur, err := parseDuration(s)
+	if err != nil {
+		return "", err
+	}
+
+	// Whole amounts are divided as integers, floats would lose the last digits
+	// of long durations
+	unit := durationMachineUnits[d.unit]
+	if dur%unit == 0 {
+		return strconv.FormatInt(int64(dur/unit), 10), nil
+	}
+
+	n := float64(dur) / float64(unit)
+	return strconv.FormatFloat(n, 'f', -1, 64), nil
+}
+
+// Lossless is true unless the style is approx, which rounds to the largest
+// unit and says so with an "about"
+func (d *Duration) Lossless() bool {
+	return d.style != "approx"
+}
+
+// fromMachine reads the machine number as a duration
+func (d *Duration) fromMachine(s string) (time.Duration, error) {
+	if !isMachineNumber(s) {
+		return 0, ErrNotANumber
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	unit := durationMachineUnits[d.unit]
+	if err != nil || n > math.MaxInt64/int64(unit) {
+		return 0, ErrTooLarge
+	}
+
+	return time.Duration(n) * unit, nil
+}
+
+// humanizeDurationShort writes every unit that isn't zero using its symbol,
+// ie: 2h 3m 4s
+func humanizeDurationShort(d time.Duration) string {
+	var parts []string
+	for _, u := range durationUnits {
+		if d >= u.size {
+			parts = append(parts, strconv.FormatInt(int64(d/u.size), 10)+u.symbol)
+			d = d % u.size
+		}
+	}
+
+	if len(parts) == 0 {
+		return "0s"
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// humanizeDurationLong writes every unit that isn't zero using its name, ie:
+// 2 hours, 3 minutes, 4 seconds
+func humanizeDurationLong(d time.Duration) string {
+	var parts []string
+	for _, u := range durationUnits {
+		if d >= u.size {
+			parts = append(parts, pluralize(int64(d/u.size), u.name))
+			d = d % u.size
+		}
+	}
+
+	if len(parts) == 0 {
+		return "0 seconds"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// humanizeDurationApprox rounds the duration to its largest unit, ie: 7384 ->
+// about 2 hours. When nothing was lost to rounding the "about" is left out
+func humanizeDurationApprox(d time.Duration) string {
+	if d == 0 {
+		return "0 seconds"
+	}
+
+	idx := 0
+	for d < durationUnits[idx].size {
+		idx++
+	}
+
+	n := int64(math.Round(float64(d) / float64(durationUnits[idx].size)))
+
+	// Rounding can push the value into the next unit, ie: 59m 50s is about an
+	// hour rather than about 60 minutes
+	if idx > 0 && time.Duration(n)*durationUnits[idx].size >= durationUnits[idx-1].size {
+		idx--
+		n = int64(math.Round(float64(d) / float64(durationUnits[idx].size)))
+	}
+
+	u := durationUnits[idx]
+	if time.Duration(n)*u.size == d {
+		return pluralize(n, u.name)
+	}
+
+	if n == 1 {
+		article := "a "
+		if u.name == "hour" {
+			article = "an "
+		}
+		return "about " + article + u.name
+	}
+
+	return "about " + pluralize(n, u.name)
+}
+
+// pluralize writes the amount followed by the name, adding an `s` when the
+// amount isn't 1
+func pluralize(n int64, name string) string {
+	out := strconv.FormatInt(n, 10) + " " + name
+	if n != 1 {
+		out += "s"
+	}
+	return out
+}
+
+// parseDuration tries each of the notations we know about in turn
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrNotADuration
+	}
+
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+
+	for _, parse := range []func(string) (time.Duration, error){parseCompactDuration, parseISODuration, parseEnglishDuration} {
+		if d, err := parse(s); err == nil {
+			return d, nil
+		} else if err == ErrTooLarge {
+			return 0, err
+		}
+	}
+
+	return 0, ErrNotADuration
+}
+
+// parseCompactDuration reads Go style durations that can also have spaces,
+// days and weeks in them, ie: 1d 2h 3m which is what the short style writes
+func parseCompactDuration(s string) (time.Duration, error) {
+	s = strings.Replace(s, " ", "", -1)
+	token := `([0-9]*\.?[0-9]+)(ns|us|µs|μs|ms|s|m|h|d|w)`
+	if !regexp.MustCompile(`^(` + token + `)+$`).MatchString(s) {
+		return 0, ErrNotADuration
+	}
+
+	symbols := map[string]time.Duration{"w": 7 * 24 * time.Hour, "us": time.Microsecond, "μs": time.Microsecond}
+	for _, u := range durationUnits {
+		symbols[u.symbol] = u.size
+	}
+
+	var total durationSum
+	for _, match := range regexp.MustCompile(token).FindAllStringSubmatch(s, -1) {
+		n, _ := strconv.ParseFloat(match[1], 64)
+		total.add(n, symbols[match[2]])
+	}
+
+	return total.duration()
+}
+
+// parseISODuration reads ISO-8601 durations, ie: P1DT2H, PT1.5S, P2W
+func parseISODuration(s string) (time.Duration, error) {
+	n := `([0-9]+(?:[.,][0-9]+)?)`
+	r := regexp.MustCompile(`^P(?:` + n + `Y)?(?:` + n + `M)?(?:` + n + `W)?(?:` + n + `D)?(?:T(?:` + n + `H)?(?:` + n + `M)?(?:` + n + `S)?)?$`)
+	s = strings.ToUpper(s)
+	match := r.FindStringSubmatch(s)
+	if match == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, ErrNotADuration
+	}
+
+	day := 24 * time.Hour
+	sizes := []time.Duration{365 * day, 30 * day, 7 * day, day, time.Hour, time.Minute, time.Second}
+
+	total := 0.0
+	for i, size := range sizes {
+		if match[i+1] == "" {
+			continue
+		}
+		v, _ := strconv.ParseFloat(strings.Replace(match[i+1], ",", ".", 1), 64)
+		total += v * float64(size)
+	}
+
+	return toDuration(total)
+}
+
+// parseEnglishDuration reads durations written out in words, ie: "an hour and
+// a half", "2 hours, 3 minutes", "half a day", "ninety minutes"
+func parseEnglishDuration(s string) (time.Duration, error) {
+	s = strings.NewReplacer(",", " ", "-", " ").Replace(strings.ToLower(s))
+
+	var total durationSum
+	var last time.Duration
+	var quantity []string
+	for _, w := range strings.Fields(s) {
+		if contains(durationFillers, w) {
+			continue
+		}
+
+		unit, ok := durationWords[w]
+		if !ok {
+			quantity = append(quantity, w)
+			continue
+		}
+
+		n, err := parseDurationQuantity(quantity)
+		if err != nil {
+			return 0, err
+		}
+		total.add(n, unit)
+		last, quantity = unit, nil
+	}
+
+	if last == 0 {
+		return 0, ErrNotADuration
+	}
+
+	// Whatever is left over has to be a fraction of the last unit, ie: the
+	// "and a half" in "an hour and a half"
+	if len(quantity) > 0 {
+		n, err := parseDurationQuantity(quantity)
+		if err != nil || n >= 1 {
+			return 0, ErrNotADuration
+		}
+		total.add(n, last)
+	}
+
+	return total.duration()
+}
+
+// parseDurationQuantity reads the amount that goes before a unit, which can be
+// a number, words or a fraction, ie: 2, 1.5, two, a, one and a half, half an
+func parseDurationQuantity(words []string) (float64, error) {
+	var filtered []string
+	for _, w := range words {
+		if w != "and" && w != "of" {
+			filtered = append(filtered, w)
+		}
+	}
+
+	for i, w := range filtered {
+		fraction, ok := durationFractions[w]
+		if !ok {
+			continue
+		}
+
+		for _, after := range filtered[i+1:] {
+			if after != "a" && after != "an" {
+				return 0, ErrNotADuration
+			}
+		}
+
+		before := filtered[:i]
+		if len(before) > 0 && (before[len(before)-1] == "a" || before[len(before)-1] == "an") {
+			before = before[:len(before)-1]
+		}
+
+		whole := 0.0
+		if len(before) > 0 {
+			n, err := parseDurationQuantity(before)
+			if err != nil {
+				return 0, err
+			}
+			whole = n
+		}
+
+		// "three quarters" is a multiple of the fraction, "one and a half" is
+		// the fraction added on top
+		if strings.HasSuffix(w, "s") {
+			return whole * fraction, nil
+		}
+		return whole + fraction, nil
+	}
+
+	if len(filtered) == 1 {
+		if n, err := strconv.ParseFloat(filtered[0], 64); err == nil {
+			return n, nil
+		}
+	}
+
+	n, err := parseSpelledNumber(filtered)
+	if err != nil {
+		return 0, ErrNotADuration
+	}
+	return n, nil
+}
+
+// durationSum adds up the parts of a duration, whole amounts of a unit are
+// added as integers so that long durations don't lose their last digits to
+// floats (ie: 10764d 1h 3m 46s 831ms 495µs 149ns)
+type durationSum struct {
+	whole    time.Duration
+	fraction float64
+	tooLarge bool
+}
+
+// add puts n of the unit into the sum
+func (d *durationSum) add(n float64, unit time.Duration) {
+	if n != math.Trunc(n) || n >= float64(math.MaxInt64/int64(unit)) {
+		d.fraction += n * float64(unit)
+		return
+	}
+
+	part := time.Duration(n) * unit
+	if d.whole > math.MaxInt64-part {
+		d.tooLarge = true
+	}
+	d.whole += part
+}
+
+// duration gives back the sum, it's an error when it doesn't fit
+func (d *durationSum) duration() (time.Duration, error) {
+	fraction, err := toDuration(d.fraction)
+	if err != nil || d.tooLarge || d.whole > math.MaxInt64-fraction {
+		return 0, ErrTooLarge
+	}
+	return d.whole + fraction, nil
+}
+
+// toDuration turns an amount of nanoseconds into a duration making sure that
+// it fits
+func toDuration(ns float64) (time.Duration, error) {
+	if ns >= math.MaxInt64 {
+		return 0, ErrTooLarge
+	}
+	return time.Duration(math.Round(ns)), nil
+}
@@ -0,0 +1,135 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestDurationCanParseFromMachine(t *testing.T) {
+	tests := []struct {
+		unit string
+		in   string
+		out  bool
+		err  error
+	}{
+		{"s", "abc", false, ErrNotANumber},
+		{"s", "1.5", false, ErrNotANumber},
+		{"s", "7384", true, nil},
+		{"ns", "9223372036854775807", true, nil},
+		{"s", "9223372036854775807", false, ErrTooLarge},
+		{"s", "99999999999999999999", false, ErrTooLarge},
+	}
+
+	for i, tt := range tests {
+		duration := NewDuration(tt.unit, "short")
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := duration.CanParseFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestDurationDoFromMachine(t *testing.T) {
+	tests := []struct {
+		unit  string
+		style string
+		in    string
+		out   string
+	}{
+		{"s", "short", "0", "0s"},
+		{"s", "short", "7384", "2h 3m 4s"},
+		{"s", "short", "90061", "1d 1h 1m 1s"},
+		{"ms", "short", "1500", "1s 500ms"},
+		{"ns", "short", "1500", "1µs 500ns"},
+		{"us", "short", "61000000", "1m 1s"},
+		{"s", "long", "7380", "2 hours, 3 minutes"},
+		{"s", "long", "3661", "1 hour, 1 minute, 1 second"},
+		{"s", "long", "0", "0 seconds"},
+		{"s", "approx", "7384", "about 2 hours"},
+		{"s", "approx", "7200", "2 hours"},
+		{"s", "approx", "3590", "about an hour"},
+		{"s", "approx", "100", "about 2 minutes"},
+		{"s", "approx", "200000", "about 2 days"},
+		{"ms", "approx", "1200", "about a second"},
+		// Should fall back to the defaults
+		{"furlongs", "fancy", "60", "1m"},
+	}
+
+	for i, tt := range tests {
+		duration := NewDuration(tt.unit, tt.style)
+		t.Run(tt.in, func(t *testing.T) {
+			got, _ := duration.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+		})
+	}
+}
+
+func TestDurationDoIntoMachine(t *testing.T) {
+	tests := []struct {
+		unit string
+		in   string
+		out  string
+		err  error
+	}{
+		// Go durations
+		{"s", "1h30m", "5400", nil},
+		{"s", "1.5h", "5400", nil},
+		{"ms", "300ms", "300", nil},
+		{"s", "-2m", "-120", nil},
+		// Our own output
+		{"s", "2h 3m 4s", "7384", nil},
+		{"s", "1d 1h 1m 1s", "90061", nil},
+		{"s", "1w", "604800", nil},
+		{"s", "1s 500ms", "1.5", nil},
+		{"ms", "1s 500ms", "1500", nil},
+		{"s", "2 hours, 3 minutes", "7380", nil},
+		{"s", "about 2 hours", "7200", nil},
+		{"s", "about an hour", "3600", nil},
+		// ISO-8601
+		{"s", "P1DT2H", "93600", nil},
+		{"s", "PT90M", "5400", nil},
+		{"s", "PT1.5S", "1.5", nil},
+		{"s", "P2W", "1209600", nil},
+		{"s", "P1Y", "31536000", nil},
+		{"s", "pt1m", "60", nil},
+		{"s", "P", "", ErrNotADuration},
+		{"s", "P1DT", "", ErrNotADuration},
+		// English
+		{"s", "an hour and a half", "5400", nil},
+		{"s", "one and a half hours", "5400", nil},
+		{"s", "half an hour", "1800", nil},
+		{"s", "a quarter of an hour", "900", nil},
+		{"s", "three quarters of an hour", "2700", nil},
+		{"s", "ninety minutes", "5400", nil},
+		{"s", "twenty-five seconds", "25", nil},
+		{"s", "2 hours and 30 minutes", "9000", nil},
+		{"s", "2 hrs 30 mins", "9000", nil},
+		{"s", "a day and a half", "129600", nil},
+		{"s", "an hour and 5", "", ErrNotADuration},
+		{"s", "hour", "", ErrNotADuration},
+		{"s", "some minutes", "", ErrNotADuration},
+		{"s", "banana", "", ErrNotADuration},
+		{"s", "1000", "", ErrNotADuration},
+		{"s", "", "", ErrNotADuration},
+		{"s", "9999999999h", "", ErrTooLarge},
+	}
+
+	for i, tt := range tests {
+		duration := NewDuration(tt.unit, "short")
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := duration.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
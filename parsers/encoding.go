@@ -0,0 +1,250 @@
+package parsers
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var ErrUnknownEncoding error = errors.New("Unknown encoding, use one of: base64, base64url, base32, base58, hex")
+var ErrNotEncoded error = errors.New("Not encoded text")
+
+// base58Alphabet is the one used by Bitcoin (and IPFS), it leaves out the
+// characters that look alike: 0, O, I and l
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// encodingMinLength is the shortest input that is decoded when the encoding
+// has to be guessed, anything shorter is too likely to be a word or a number
+const encodingMinLength = 8
+
+// encodingSchemes are the encodings we know about in the order they're tried
+// when guessing, from the most restrictive alphabet to the least
+var encodingSchemes = []string{"hex", "base32", "base64", "base58"}
+
+// Encoding decodes (and encodes) binary to text encodings. The machine side of
+// things is the encoded text, the human side is what it decodes to, ie:
+// 	aGVsbG8gd29ybGQK <-> hello world
+//
+// When the decoded bytes aren't text they're shown as a hexdump so that
+// nothing unprintable ends up in the terminal.
+//
+// When the scheme isn't given it's guessed, but only inputs that decode into
+// text are accepted since short alphanumeric strings are valid in most of
+// these encodings and are far more likely to be words or numbers
+type Encoding struct {
+	// scheme is one of `encodingSchemes` or base64url, an empty string means
+	// guess it when decoding and use base64 when encoding
+	scheme string
+}
+
+// NewEncoding constructs an Encoding parser
+func NewEncoding(scheme string) (*Encoding, error) {
+	if scheme != "" && scheme != "base64url" && !contains(encodingSchemes, scheme) {
+		return nil, ErrUnknownEncoding
+	}
+	return &Encoding{scheme: scheme}, nil
+}
+
+// CanParseFromMachine determines if the input decodes with the scheme (or
+// with any of them when guessing)
+func (e *Encoding) CanParseFromMachine(s string) (bool, error) {
+	if _, err := e.decode(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CanParseIntoMachine is always true since anything can be encoded
+func (e *Encoding) CanParseIntoMachine(s string) (bool, error) {
+	return true, nil
+}
+
+// DoFromMachine decodes the input, binary data is written as a hexdump
+func (e *Encoding) DoFromMachine(s string) (string, error) {
+	decoded, err := e.decode(s)
+	if err != nil {
+		return "", err
+	}
+
+	if isPrintable(decoded) {
+		return strings.TrimRight(string(decoded), "\r\n"), nil
+	}
+	return strings.TrimRight(hex.Dump(decoded), "\n"), nil
+}
+
+// DoIntoMachine encodes the input with the scheme, base64 when there's none
+func (e *Encoding) DoIntoMachine(s string) (string, error) {
+	data := []byte(s)
+	switch e.scheme {
+	case "base64url":
+		return base64.URLEncoding.EncodeToString(data), nil
+	case "base32":
+		return base32.StdEncoding.EncodeToString(data), nil
+	case "base58":
+		return encodeBase58(data), nil
+	case "hex":
+		return hex.EncodeToString(data), nil
+	default:
+		return base64.StdEncoding.EncodeToString(data), nil
+	}
+}
+
+// Lossless is false, decoding and encoding again only gives back the input
+// when it was written the way the encoder writes it
+func (e *Encoding) Lossless() bool {
+	return false
+}
+
+// decode uses the parser's scheme or tries all of them
+func (e *Encoding) decode(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if e.scheme != "" {
+		return decodeScheme(e.scheme, s)
+	}
+
+	for _, scheme := range encodingSchemes {
+		if decoded, err := e.guess(scheme, s); err == nil {
+			return decoded, nil
+		}
+	}
+	return nil, ErrNotEncoded
+}
+
+// guess decodes the input with the scheme but only accepts it when it's
+// unlikely to be a coincidence: long enough, not a plain number and decodes
+// into something that reads like text
+func (e *Encoding) guess(scheme, s string) ([]byte, error) {
+	if len(s) < encodingMinLength || regexp.MustCompile(`^[0-9]+$`).MatchString(s) {
+		return nil, ErrNotEncoded
+	}
+
+	decoded, err := decodeScheme(scheme, s)
+	if err != nil {
+		return nil, err
+	}
+
+	if !looksLikeText(decoded) {
+		return nil, ErrNotEncoded
+	}
+	return decoded, nil
+}
+
+// decodeScheme decodes the input with the scheme. Base64 takes both the
+// standard and URL safe alphabets with or without padding
+func decodeScheme(scheme, s string) ([]byte, error) {
+	var decoded []byte
+	var err error
+
+	switch scheme {
+	case "hex":
+		decoded, err = hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	case "base32":
+		decoded, err = base32.StdEncoding.DecodeString(strings.ToUpper(s))
+		if err != nil {
+			decoded, err = base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(s))
+		}
+	case "base58":
+		decoded, err = decodeBase58(s)
+	default:
+		for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+			if decoded, err = enc.DecodeString(s); err == nil {
+				break
+			}
+		}
+	}
+
+	if err != nil || len(decoded) == 0 {
+		return nil, ErrNotEncoded
+	}
+	return decoded, nil
+}
+
+// encodeBase58 writes the bytes as a big number in base 58, each leading zero
+// byte is written as a `1`
+func encodeBase58(data []byte) string {
+	n := new(big.Int).SetBytes(data)
+	base := big.NewInt(58)
+	mod := new(big.Int)
+
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, base, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// decodeBase58 reads a base 58 number back into bytes
+func decodeBase58(s string) ([]byte, error) {
+	n := new(big.Int)
+	base := big.NewInt(58)
+	for _, c := range s {
+		idx := strings.IndexRune(base58Alphabet, c)
+		if idx < 0 {
+			return nil, ErrNotEncoded
+		}
+		n.Mul(n, base)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+
+	zeros := len(s) - len(strings.TrimLeft(s, base58Alphabet[:1]))
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// isPrintable determines if the bytes are text that can be shown as is
+func isPrintable(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// looksLikeText determines if the bytes are printable ASCII mostly made of
+// letters, digits and spaces with at least something that could be a word in
+// it. Random bytes can be printable by chance (ie: a word read as base58) but
+// they rarely read like that
+func looksLikeText(data []byte) bool {
+	if !isPrintable(data) {
+		return false
+	}
+
+	wordy := 0
+	for _, b := range data {
+		if b > unicode.MaxASCII {
+			return false
+		}
+		if unicode.IsLetter(rune(b)) || unicode.IsDigit(rune(b)) || b == ' ' {
+			wordy++
+		}
+	}
+
+	word := regexp.MustCompile(`(?i)[a-z]*[aeiouy][a-z]*`)
+	hasWord := false
+	for _, w := range word.FindAll(data, -1) {
+		hasWord = hasWord || len(w) >= 3
+	}
+
+	return hasWord && wordy*20 >= len(data)*17
+}
@@ -0,0 +1,88 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestEncodingDoFromMachine(t *testing.T) {
+	tests := []struct {
+		scheme string
+		in     string
+		out    string
+		err    error
+	}{
+		// Should guess the scheme
+		{"", "aGVsbG8gd29ybGQK", "hello world", nil},
+		{"", "aGVsbG8gd29ybGQ=", "hello world", nil},
+		{"", "aGVsbG8gd29ybGQ", "hello world", nil},
+		{"", "aXMgaXQgb2s_PyB5ZXM", "is it ok?? yes", nil},
+		{"", "aXMgaXQgb2s/PyB5ZXM=", "is it ok?? yes", nil},
+		{"", "PDw/Pz8+Pj4=", "", ErrNotEncoded},
+		{"", "NBSWY3DPEB3W64TMMQ======", "hello world", nil},
+		{"", "NBSWY3DPEB3W64TMMQ", "hello world", nil},
+		{"", "StV1DL6CwTryKyV", "hello world", nil},
+		{"", "68656c6c6f20776f726c64", "hello world", nil},
+		// Should guard against words and numbers
+		{"", "password", "", ErrNotEncoded},
+		{"", "insecure", "", ErrNotEncoded},
+		{"", "12345678", "", ErrNotEncoded},
+		{"", "deadbeef", "", ErrNotEncoded},
+		{"", "aGk=", "", ErrNotEncoded},
+		{"", "AAECAwQFBgc=", "", ErrNotEncoded},
+		// Should use a hexdump for binary when the scheme is given
+		{"base64", "AAECAwQFBgc=", "00000000  00 01 02 03 04 05 06 07                           |........|", nil},
+		{"hex", "deadbeef", "00000000  de ad be ef                                       |....|", nil},
+		{"base64", "aGk=", "hi", nil},
+		{"base64url", "PDw_Pz8-Pj4", "<<???>>>", nil},
+		{"base58", "1112", "00000000  00 00 00 01                                       |....|", nil},
+		{"base32", "NBUQ====", "hi", nil},
+		{"hex", "0x6869", "hi", nil},
+		{"hex", "xyz", "", ErrNotEncoded},
+		{"base58", "0OIl", "", ErrNotEncoded},
+	}
+
+	for i, tt := range tests {
+		encoding, _ := NewEncoding(tt.scheme)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := encoding.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestEncodingDoIntoMachine(t *testing.T) {
+	tests := []struct {
+		scheme string
+		in     string
+		out    string
+	}{
+		{"", "hello world", "aGVsbG8gd29ybGQ="},
+		{"base64", "hello world", "aGVsbG8gd29ybGQ="},
+		{"base64url", "<<???>>>", "PDw_Pz8-Pj4="},
+		{"base32", "hello world", "NBSWY3DPEB3W64TMMQ======"},
+		{"base58", "hello world", "StV1DL6CwTryKyV"},
+		{"base58", "\x00\x00hi", "118wr"},
+		{"hex", "hello world", "68656c6c6f20776f726c64"},
+	}
+
+	for i, tt := range tests {
+		encoding, _ := NewEncoding(tt.scheme)
+		t.Run(tt.in, func(t *testing.T) {
+			got, _ := encoding.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+		})
+	}
+}
+
+func TestNewEncoding(t *testing.T) {
+	if _, err := NewEncoding("rot13"); err != ErrUnknownEncoding {
+		t.Errorf("want `%t` ; got `%t`", ErrUnknownEncoding, err)
+	}
+}
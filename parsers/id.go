@@ -0,0 +1,298 @@
+package parsers
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNotAnID error = errors.New("Not a known ID, ie: a UUID, ULID, KSUID, Snowflake or MongoDB ObjectID")
+var ErrBadEpoch error = errors.New("Unknown epoch, use twitter, discord or the epoch in milliseconds")
+
+// SnowflakeTwitterEpoch is when Twitter's Snowflake IDs start counting, in
+// milliseconds, it's the default since they're the original ones
+const SnowflakeTwitterEpoch int64 = 1288834974657
+
+// snowflakeEpochs are the epochs (in milliseconds) of well known Snowflake IDs
+var snowflakeEpochs = map[string]int64{
+	"twitter": SnowflakeTwitterEpoch,
+	"discord": 1420070400000,
+}
+
+// uuidEpoch is the start of the Gregorian calendar (1582-10-15) counted in
+// 100 nanosecond intervals before the Unix epoch, UUID v1 and v6 count from it
+const uuidEpoch = 0x01B21DD213814000
+
+// ksuidEpoch is when KSUIDs start counting seconds from
+const ksuidEpoch = 1400000000
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// uuidVersions describes each of the UUID versions
+var uuidVersions = map[int]string{
+	1: "time and node",
+	2: "DCE security",
+	3: "MD5 of a name",
+	4: "random",
+	5: "SHA-1 of a name",
+	6: "time ordered, time and node",
+	7: "time ordered",
+	8: "custom",
+}
+
+// Id tells which kind of identifier the input is and takes apart the ones that
+// have something in them, mostly the time they were made at:
+// 	UUID      v1 and v6 have a time and a node (usually a MAC address), v7 a time
+// 	ULID      a time and randomness
+// 	KSUID     a time and a payload
+// 	Snowflake a time, worker, process and sequence (Twitter, Discord...)
+// 	ObjectID  MongoDB's time, randomness and counter
+//
+// Only Snowflakes made before now are accepted since any big number would
+// otherwise be one
+type Id struct {
+	// epoch is when the Snowflake IDs start counting, in milliseconds
+	epoch int64
+	// loc is the time zone the times are shown in
+	loc *time.Location
+	// now is the clock used to reject Snowflakes from the future
+	now func() time.Time
+}
+
+// NewId constructs an Id parser. `loc` defaults to the local time zone and
+// `now` to the system clock
+func NewId(epoch int64, loc *time.Location, now func() time.Time) *Id {
+	if loc == nil {
+		loc = time.Local
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &Id{epoch: epoch, loc: loc, now: now}
+}
+
+// ParseSnowflakeEpoch reads the epoch by name (twitter, discord) or as a
+// number of milliseconds
+func ParseSnowflakeEpoch(s string) (int64, error) {
+	if epoch, ok := snowflakeEpochs[strings.ToLower(s)]; ok {
+		return epoch, nil
+	}
+
+	epoch, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || epoch < 0 {
+		return 0, ErrBadEpoch
+	}
+	return epoch, nil
+}
+
+// CanParseFromMachine determines if the input is one of the IDs we know about
+func (i *Id) CanParseFromMachine(s string) (bool, error) {
+	if _, err := i.DoFromMachine(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CanParseIntoMachine is always false since IDs aren't made here
+func (i *Id) CanParseIntoMachine(s string) (bool, error) {
+	return false, ErrNotYetImplemented
+}
+
+// DoFromMachine writes what kind of ID the input is along with what's in it,
+// one property per line
+func (i *Id) DoFromMachine(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	for _, describe := range []func(string) ([][2]string, error){i.describeUUID, i.describeULID, i.describeKSUID, i.describeObjectID, i.describeSnowflake} {
+		if lines, err := describe(s); err == nil {
+			return alignLines(lines), nil
+		}
+	}
+
+	return "", ErrNotAnID
+}
+
+// DoIntoMachine isn't supported, see CanParseIntoMachine
+func (i *Id) DoIntoMachine(s string) (string, error) {
+	return "", ErrNotYetImplemented
+}
+
+// Lossless is false, IDs are only described
+func (i *Id) Lossless() bool {
+	return false
+}
+
+// describeUUID takes apart UUIDs written with dashes, optionally in braces or
+// as a URN
+func (i *Id) describeUUID(s string) ([][2]string, error) {
+	s = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(s), "urn:uuid:"), "{"), "}")
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`).MatchString(s) {
+		return nil, ErrNotAnID
+	}
+
+	b, _ := hex.DecodeString(strings.Replace(s, "-", "", -1))
+	switch s {
+	case "00000000-0000-0000-0000-000000000000":
+		return [][2]string{{"type", "UUID nil"}}, nil
+	case "ffffffff-ffff-ffff-ffff-ffffffffffff":
+		return [][2]string{{"type", "UUID max"}}, nil
+	}
+
+	// Only the RFC 4122 variant (10xx) has versions
+	if b[8]>>6 != 2 {
+		variant := "Microsoft"
+		if b[8]>>7 == 0 {
+			variant = "NCS"
+		}
+		return [][2]string{{"type", "UUID (" + variant + " variant)"}}, nil
+	}
+
+	version := int(b[6] >> 4)
+	kind, ok := uuidVersions[version]
+	if !ok {
+		return [][2]string{{"type", fmt.Sprintf("UUID v%d (unknown)", version)}}, nil
+	}
+
+	lines := [][2]string{{"type", fmt.Sprintf("UUID v%d (%s)", version, kind)}}
+
+	var ticks uint64
+	switch version {
+	case 1:
+		ticks = uint64(binary.BigEndian.Uint16(b[6:8])&0x0fff)<<48 | uint64(binary.BigEndian.Uint16(b[4:6]))<<32 | uint64(binary.BigEndian.Uint32(b[0:4]))
+	case 6:
+		ticks = uint64(binary.BigEndian.Uint32(b[0:4]))<<28 | uint64(binary.BigEndian.Uint16(b[4:6]))<<12 | uint64(binary.BigEndian.Uint16(b[6:8])&0x0fff)
+	case 7:
+		ms := int64(binary.BigEndian.Uint64(append([]byte{0, 0}, b[0:6]...)))
+		return append(lines, [2]string{"time", i.formatTime(time.Unix(ms/1000, (ms%1000)*1e6))}), nil
+	default:
+		return lines, nil
+	}
+
+	// The ticks are 100ns since 1582 which overflows a time.Duration, so the
+	// seconds are added on their own
+	since := int64(ticks) - uuidEpoch
+	tm := time.Unix(since/1e7, (since%1e7)*100)
+
+	node := make([]string, 6)
+	for n, octet := range b[10:16] {
+		node[n] = fmt.Sprintf("%02x", octet)
+	}
+	nodeKind := "MAC address"
+	if b[10]&1 == 1 {
+		nodeKind = "random"
+	}
+
+	return append(lines,
+		[2]string{"time", i.formatTime(tm)},
+		[2]string{"clock", strconv.Itoa(int(binary.BigEndian.Uint16(b[8:10]) & 0x3fff))},
+		[2]string{"node", strings.Join(node, ":") + " (" + nodeKind + ")"},
+	), nil
+}
+
+// describeULID takes apart ULIDs, 26 characters of Crockford's base32 where
+// the first 10 are the time in milliseconds
+func (i *Id) describeULID(s string) ([][2]string, error) {
+	s = strings.ToUpper(s)
+	if !regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`).MatchString(s) {
+		return nil, ErrNotAnID
+	}
+
+	n := new(big.Int)
+	for _, c := range s {
+		n.Lsh(n, 5)
+		n.Or(n, big.NewInt(int64(strings.IndexRune(crockfordAlphabet, c))))
+	}
+
+	b := n.FillBytes(make([]byte, 16))
+	ms := int64(binary.BigEndian.Uint64(append([]byte{0, 0}, b[0:6]...)))
+
+	return [][2]string{
+		{"type", "ULID"},
+		{"time", i.formatTime(time.Unix(ms/1000, (ms%1000)*1e6))},
+		{"random", hex.EncodeToString(b[6:])},
+	}, nil
+}
+
+// describeKSUID takes apart KSUIDs, 27 characters of base62 that make up 4
+// bytes of seconds since 2014-05-13 and 16 bytes of payload
+func (i *Id) describeKSUID(s string) ([][2]string, error) {
+	if !regexp.MustCompile(`^[0-9A-Za-z]{27}$`).MatchString(s) {
+		return nil, ErrNotAnID
+	}
+
+	n := new(big.Int)
+	for _, c := range s {
+		n.Mul(n, big.NewInt(62))
+		n.Add(n, big.NewInt(int64(strings.IndexRune(base62Alphabet, c))))
+	}
+	if n.BitLen() > 160 {
+		return nil, ErrNotAnID
+	}
+
+	b := n.FillBytes(make([]byte, 20))
+	seconds := int64(binary.BigEndian.Uint32(b[0:4])) + ksuidEpoch
+
+	return [][2]string{
+		{"type", "KSUID"},
+		{"time", i.formatTime(time.Unix(seconds, 0))},
+		{"payload", hex.EncodeToString(b[4:])},
+	}, nil
+}
+
+// describeObjectID takes apart MongoDB ObjectIDs, 12 bytes in hex made up of
+// the seconds since 1970, 5 random bytes and a counter
+func (i *Id) describeObjectID(s string) ([][2]string, error) {
+	if !regexp.MustCompile(`^[0-9a-fA-F]{24}$`).MatchString(s) {
+		return nil, ErrNotAnID
+	}
+
+	b, _ := hex.DecodeString(s)
+	counter := uint32(b[9])<<16 | uint32(b[10])<<8 | uint32(b[11])
+
+	return [][2]string{
+		{"type", "MongoDB ObjectID"},
+		{"time", i.formatTime(time.Unix(int64(binary.BigEndian.Uint32(b[0:4])), 0))},
+		{"random", hex.EncodeToString(b[4:9])},
+		{"counter", strconv.FormatUint(uint64(counter), 10)},
+	}, nil
+}
+
+// describeSnowflake takes apart Snowflake IDs, 64 bit numbers made up of the
+// milliseconds since the epoch (42 bits), the worker (5 bits), the process
+// (5 bits) and a sequence number (12 bits)
+func (i *Id) describeSnowflake(s string) ([][2]string, error) {
+	if !regexp.MustCompile(`^[1-9][0-9]{14,18}$`).MatchString(s) {
+		return nil, ErrNotAnID
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, ErrNotAnID
+	}
+
+	tm := time.Unix(0, 0).Add(time.Duration((n>>22)+i.epoch) * time.Millisecond)
+	if tm.After(i.now()) {
+		return nil, ErrNotAnID
+	}
+
+	return [][2]string{
+		{"type", "Snowflake"},
+		{"time", i.formatTime(tm)},
+		{"worker", strconv.FormatInt((n>>17)&0x1f, 10)},
+		{"process", strconv.FormatInt((n>>12)&0x1f, 10)},
+		{"sequence", strconv.FormatInt(n&0xfff, 10)},
+	}, nil
+}
+
+// formatTime writes the time in the parser's time zone with milliseconds since
+// most of these IDs have them
+func (i *Id) formatTime(tm time.Time) string {
+	return tm.In(i.loc).Format("Mon, 02 Jan 2006 15:04:05.000 MST")
+}
@@ -0,0 +1,69 @@
+package parsers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdDoFromMachine(t *testing.T) {
+	tests := []struct {
+		epoch int64
+		in    string
+		out   string
+		err   error
+	}{
+		{SnowflakeTwitterEpoch, "c232ab00-9414-11ec-b3c8-9f6bdeced846", "type:      UUID v1 (time and node)\ntime:      Tue, 22 Feb 2022 19:22:22.000 UTC\nclock:     13256\nnode:      9f:6b:de:ce:d8:46 (random)", nil},
+		{SnowflakeTwitterEpoch, "{1EC9414C-232A-6B00-B3C8-9F6BDECED846}", "type:      UUID v6 (time ordered, time and node)\ntime:      Tue, 22 Feb 2022 19:22:22.000 UTC\nclock:     13256\nnode:      9f:6b:de:ce:d8:46 (random)", nil},
+		{SnowflakeTwitterEpoch, "urn:uuid:017f22e2-79b0-7cc3-98c4-dc0c0c07398f", "type:      UUID v7 (time ordered)\ntime:      Tue, 22 Feb 2022 19:22:22.000 UTC", nil},
+		{SnowflakeTwitterEpoch, "f47ac10b-58cc-4372-a567-0e02b2c3d479", "type:      UUID v4 (random)", nil},
+		{SnowflakeTwitterEpoch, "00000000-0000-0000-0000-000000000000", "type:      UUID nil", nil},
+		{SnowflakeTwitterEpoch, "01ARZ3NDEKTSV4RRFFQ69G5FAV", "type:      ULID\ntime:      Sat, 30 Jul 2016 23:54:10.259 UTC\nrandom:    d6764c61efb99302bd5b", nil},
+		// The last ULID is past what a time.Duration can hold
+		{SnowflakeTwitterEpoch, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", "type:      ULID\ntime:      Tue, 02 Aug 10889 05:31:50.655 UTC\nrandom:    ffffffffffffffffffff", nil},
+		{SnowflakeTwitterEpoch, "0ujtsYcgvSTl8PAuAdqWYSMnLOv", "type:      KSUID\ntime:      Tue, 10 Oct 2017 04:00:47.000 UTC\npayload:   b5a1cd34b5f99d1154fb6853345c9735", nil},
+		{SnowflakeTwitterEpoch, "507f1f77bcf86cd799439011", "type:      MongoDB ObjectID\ntime:      Wed, 17 Oct 2012 21:13:27.000 UTC\nrandom:    bcf86cd799\ncounter:   4427793", nil},
+		{SnowflakeTwitterEpoch, "1541815603606036480", "type:      Snowflake\ntime:      Tue, 28 Jun 2022 16:07:40.105 UTC\nworker:    11\nprocess:   26\nsequence:  0", nil},
+		{1420070400000, "175928847299117063", "type:      Snowflake\ntime:      Sat, 30 Apr 2016 11:18:25.796 UTC\nworker:    1\nprocess:   0\nsequence:  7", nil},
+		// From the future with the Twitter epoch
+		{SnowflakeTwitterEpoch, "9223372036854775807", "", ErrNotAnID},
+		{SnowflakeTwitterEpoch, "12345", "", ErrNotAnID},
+		{SnowflakeTwitterEpoch, "hello", "", ErrNotAnID},
+		{SnowflakeTwitterEpoch, "c232ab00-9414-11ec-b3c8", "", ErrNotAnID},
+	}
+
+	for i, tt := range tests {
+		id := NewId(tt.epoch, time.UTC, fixedClock)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := id.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestParseSnowflakeEpoch(t *testing.T) {
+	tests := []struct {
+		in  string
+		out int64
+		err error
+	}{
+		{"twitter", SnowflakeTwitterEpoch, nil},
+		{"Discord", 1420070400000, nil},
+		{"1000", 1000, nil},
+		{"-1", 0, ErrBadEpoch},
+		{"myspace", 0, ErrBadEpoch},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseSnowflakeEpoch(tt.in)
+			if got != tt.out || err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%d`, `%v` ; got `%d`, `%v`", i, tt.in, tt.out, tt.err, got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,436 @@
+package parsers
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256" // registers the SHA-256 hash
+	_ "crypto/sha512" // registers the SHA-384 and SHA-512 hashes
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var ErrNotAJWT error = errors.New("Not a JWT, ie: header.claims.signature")
+var ErrBadKey error = errors.New("Unusable key, expected a PEM public key, a certificate or a JWKS")
+var ErrInvalidSignature error = errors.New("The signature of the JWT is invalid")
+var ErrUnsupportedAlg error = errors.New("Can't verify the signature with that algorithm and key")
+
+// jwtTimeClaims are the registered claims that hold a point in time
+var jwtTimeClaims = []string{"exp", "iat", "nbf"}
+
+// jwtHashes are the hashes used by each size of the algorithms
+var jwtHashes = map[string]crypto.Hash{
+	"256": crypto.SHA256,
+	"384": crypto.SHA384,
+	"512": crypto.SHA512,
+}
+
+// Jwt decodes JSON Web Tokens so that they can be read, ie:
+// 	header:
+// 	  alg: HS256
+// 	claims:
+// 	  exp: 1700010800 (Tue, 14 Nov 2023 01:13:20 UTC, expires in 3 hours)
+// 	  sub: 1234567890
+// 	signature: valid
+//
+// Tokens that aren't signed (alg none) or that are expired are flagged. The
+// signature is checked when there's a secret (HS256, HS384, HS512) or a public
+// key (RS*, PS*, ES*) to check it with, everything is done offline
+type Jwt struct {
+	// secret is the shared secret for HMAC signatures
+	secret []byte
+	// keys are the public keys for RSA and ECDSA signatures by their key id,
+	// keys without an id are under ""
+	keys map[string][]crypto.PublicKey
+	// loc is the time zone the dates are shown in
+	loc *time.Location
+	// now is the clock used to tell if the token expired
+	now func() time.Time
+}
+
+// NewJwt constructs a Jwt parser. The key can be a PEM encoded public key or
+// certificate or a JWKS (or a single JWK), `loc` defaults to the local time
+// zone and `now` to the system clock
+func NewJwt(secret string, key []byte, loc *time.Location, now func() time.Time) (*Jwt, error) {
+	j := &Jwt{secret: []byte(secret), keys: map[string][]crypto.PublicKey{}, loc: loc, now: now}
+	if loc == nil {
+		j.loc = time.Local
+	}
+	if now == nil {
+		j.now = time.Now
+	}
+
+	if len(key) > 0 {
+		if err := j.loadKeys(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return j, nil
+}
+
+// CanParseFromMachine determines if the input is made up of three base64url
+// parts and the first one is a JOSE header
+func (j *Jwt) CanParseFromMachine(s string) (bool, error) {
+	if _, _, err := splitJwt(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CanParseIntoMachine is always false since we don't make tokens
+func (j *Jwt) CanParseIntoMachine(s string) (bool, error) {
+	return false, ErrNotYetImplemented
+}
+
+// DoFromMachine writes the header and claims one per line with the dates
+// explained. When the signature is checked and it doesn't match, the token is
+// still written out along with ErrInvalidSignature
+func (j *Jwt) DoFromMachine(s string) (string, error) {
+	header, claims, err := splitJwt(s)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	var warnings []string
+
+	out.WriteString("header:\n")
+	for _, k := range sortedKeys(header) {
+		out.WriteString("  " + k + ": " + jsonValue(header[k]) + "\n")
+	}
+
+	alg, _ := header["alg"].(string)
+	if strings.EqualFold(alg, "none") {
+		warnings = append(warnings, "alg is none, the token isn't signed")
+	}
+
+	out.WriteString("claims:\n")
+	for _, k := range sortedKeys(claims) {
+		value := jsonValue(claims[k])
+		if n, ok := claims[k].(json.Number); ok && contains(jwtTimeClaims, k) {
+			value, warnings = j.explainTime(k, n, value, warnings)
+		}
+		out.WriteString("  " + k + ": " + value + "\n")
+	}
+
+	verified, err := j.verify(s, alg)
+	switch {
+	case err == ErrInvalidSignature:
+		warnings = append(warnings, "the signature doesn't match")
+		out.WriteString("signature: invalid\n")
+	case err != nil:
+		out.WriteString("signature: not checked (" + err.Error() + ")\n")
+	case verified:
+		out.WriteString("signature: valid\n")
+	default:
+		out.WriteString("signature: not checked\n")
+	}
+
+	if len(warnings) > 0 {
+		out.WriteString("warnings:\n")
+		for _, w := range warnings {
+			out.WriteString("  " + w + "\n")
+		}
+	}
+
+	if err == ErrInvalidSignature {
+		return strings.TrimRight(out.String(), "\n"), err
+	}
+	return strings.TrimRight(out.String(), "\n"), nil
+}
+
+// DoIntoMachine isn't supported, see CanParseIntoMachine
+func (j *Jwt) DoIntoMachine(s string) (string, error) {
+	return "", ErrNotYetImplemented
+}
+
+// Lossless is false, tokens are only described
+func (j *Jwt) Lossless() bool {
+	return false
+}
+
+// explainTime adds the date and how far it is from now to one of the time
+// claims, flagging the token when it's expired or not valid yet
+func (j *Jwt) explainTime(claim string, n json.Number, value string, warnings []string) (string, []string) {
+	epoch, err := n.Float64()
+	if err != nil {
+		return value, warnings
+	}
+
+	tm := time.Unix(int64(epoch), 0)
+	now := j.now()
+	relative := humanizeRelative(tm, now)
+
+	var phrase string
+	switch claim {
+	case "exp":
+		phrase = "expires " + relative
+		if !tm.After(now) {
+			phrase = "expired " + relative
+			warnings = append(warnings, "the token expired "+relative)
+		}
+	case "nbf":
+		phrase = "became valid " + relative
+		if tm.After(now) {
+			phrase = "becomes valid " + relative
+			warnings = append(warnings, "the token isn't valid yet, it becomes valid "+relative)
+		}
+	default:
+		phrase = "issued " + relative
+	}
+
+	return value + " (" + tm.In(j.loc).Format(time.RFC1123) + ", " + phrase + ")", warnings
+}
+
+// verify checks the signature when there's a secret or key to check it with,
+// it gives back false without an error when there's nothing to check with
+func (j *Jwt) verify(token, alg string) (bool, error) {
+	if len(alg) != 5 || jwtHashes[alg[2:]] == 0 {
+		if len(j.secret) > 0 || len(j.keys) > 0 {
+			return false, ErrUnsupportedAlg
+		}
+		return false, nil
+	}
+
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	signed := []byte(parts[0] + "." + parts[1])
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false, ErrInvalidSignature
+	}
+
+	hash := jwtHashes[alg[2:]]
+	family := alg[:2]
+
+	if family == "HS" {
+		if len(j.secret) == 0 {
+			return false, nil
+		}
+		mac := hmac.New(hash.New, j.secret)
+		mac.Write(signed)
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return false, ErrInvalidSignature
+		}
+		return true, nil
+	}
+
+	if len(j.keys) == 0 {
+		return false, nil
+	}
+
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	var header map[string]interface{}
+	raw, _ := base64.RawURLEncoding.DecodeString(parts[0])
+	json.Unmarshal(raw, &header)
+	kid, _ := header["kid"].(string)
+
+	candidates := j.keys[kid]
+	if kid != "" && len(candidates) == 0 {
+		candidates = j.keys[""]
+	}
+
+	tried := false
+	for _, key := range candidates {
+		switch k := key.(type) {
+		case *rsa.PublicKey:
+			if family == "RS" {
+				tried = true
+				if rsa.VerifyPKCS1v15(k, hash, digest, signature) == nil {
+					return true, nil
+				}
+			}
+			if family == "PS" {
+				tried = true
+				if rsa.VerifyPSS(k, hash, digest, signature, nil) == nil {
+					return true, nil
+				}
+			}
+		case *ecdsa.PublicKey:
+			size := (k.Curve.Params().BitSize + 7) / 8
+			if family == "ES" && len(signature) == 2*size {
+				tried = true
+				r := new(big.Int).SetBytes(signature[:size])
+				s := new(big.Int).SetBytes(signature[size:])
+				if ecdsa.Verify(k, digest, r, s) {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	if !tried {
+		return false, ErrUnsupportedAlg
+	}
+	return false, ErrInvalidSignature
+}
+
+// loadKeys reads PEM encoded public keys and certificates or a JWKS
+func (j *Jwt) loadKeys(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		return j.loadJwks(data)
+	}
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		var key crypto.PublicKey
+		var err error
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		case "CERTIFICATE":
+			var cert *x509.Certificate
+			if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+				key = cert.PublicKey
+			}
+		default:
+			continue
+		}
+
+		if err != nil {
+			return ErrBadKey
+		}
+		j.keys[""] = append(j.keys[""], key)
+	}
+
+	if len(j.keys) == 0 {
+		return ErrBadKey
+	}
+	return nil
+}
+
+// loadJwks reads a JSON Web Key Set, or a single key, with RSA and EC keys
+func (j *Jwt) loadJwks(data []byte) error {
+	type jwk struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		Crv string `json:"crv"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		X   string `json:"x"`
+		Y   string `json:"y"`
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return ErrBadKey
+	}
+	if len(set.Keys) == 0 {
+		var single jwk
+		json.Unmarshal(data, &single)
+		set.Keys = []jwk{single}
+	}
+
+	number := func(s string) *big.Int {
+		b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+		if err != nil || len(b) == 0 {
+			return nil
+		}
+		return new(big.Int).SetBytes(b)
+	}
+
+	curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}
+
+	for _, k := range set.Keys {
+		var key crypto.PublicKey
+		switch k.Kty {
+		case "RSA":
+			n, e := number(k.N), number(k.E)
+			if n == nil || e == nil || !e.IsInt64() {
+				return ErrBadKey
+			}
+			key = &rsa.PublicKey{N: n, E: int(e.Int64())}
+		case "EC":
+			x, y, curve := number(k.X), number(k.Y), curves[k.Crv]
+			if x == nil || y == nil || curve == nil {
+				return ErrBadKey
+			}
+			key = &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		default:
+			continue
+		}
+
+		j.keys[k.Kid] = append(j.keys[k.Kid], key)
+		if k.Kid != "" {
+			j.keys[""] = append(j.keys[""], key)
+		}
+	}
+
+	if len(j.keys) == 0 {
+		return ErrBadKey
+	}
+	return nil
+}
+
+// splitJwt decodes the header and the claims of the token
+func splitJwt(s string) (map[string]interface{}, map[string]interface{}, error) {
+	s = strings.TrimSpace(s)
+	if !regexp.MustCompile(`^[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`).MatchString(s) {
+		return nil, nil, ErrNotAJWT
+	}
+
+	parts := strings.Split(s, ".")
+	var decoded [2]map[string]interface{}
+	for i := range decoded {
+		raw, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if err != nil {
+			return nil, nil, ErrNotAJWT
+		}
+
+		d := json.NewDecoder(bytes.NewReader(raw))
+		d.UseNumber()
+		if err := d.Decode(&decoded[i]); err != nil || decoded[i] == nil {
+			return nil, nil, ErrNotAJWT
+		}
+	}
+
+	if _, ok := decoded[0]["alg"]; !ok {
+		return nil, nil, ErrNotAJWT
+	}
+
+	return decoded[0], decoded[1], nil
+}
+
+// jsonValue writes the value the way it'd be in JSON, minus the quotes for
+// strings
+func jsonValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	out, _ := json.Marshal(v)
+	return string(out)
+}
+
+// sortedKeys gives back the keys of the map in alphabetical order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
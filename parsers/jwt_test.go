@@ -0,0 +1,167 @@
+package parsers
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// makeJwt puts a token together, the signature is made by `sign` which gets
+// the signed part of the token
+func makeJwt(header, claims string, sign func([]byte) []byte) string {
+	enc := base64.RawURLEncoding
+	signed := enc.EncodeToString([]byte(header)) + "." + enc.EncodeToString([]byte(claims))
+	return signed + "." + enc.EncodeToString(sign([]byte(signed)))
+}
+
+func hs256(secret string) func([]byte) []byte {
+	return func(data []byte) []byte {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(data)
+		return mac.Sum(nil)
+	}
+}
+
+func unsigned([]byte) []byte {
+	return nil
+}
+
+func TestJwtDoFromMachine(t *testing.T) {
+	header := `{"alg":"HS256","typ":"JWT"}`
+	claims := `{"sub":"1234567890","name":"John Doe","admin":true,"exp":1700010800,"iat":1699740800}`
+	token := makeJwt(header, claims, hs256("s3cret"))
+
+	body := strings.Join([]string{
+		"header:",
+		"  alg: HS256",
+		"  typ: JWT",
+		"claims:",
+		"  admin: true",
+		"  exp: 1700010800 (Wed, 15 Nov 2023 01:13:20 UTC, expires in 3 hours)",
+		"  iat: 1699740800 (Sat, 11 Nov 2023 22:13:20 UTC, issued 3 days ago)",
+		"  name: John Doe",
+		"  sub: 1234567890",
+	}, "\n")
+
+	tests := []struct {
+		secret string
+		in     string
+		out    string
+		err    error
+	}{
+		{"", token, body + "\nsignature: not checked", nil},
+		{"s3cret", token, body + "\nsignature: valid", nil},
+		{"wrong", token, body + "\nsignature: invalid\nwarnings:\n  the signature doesn't match", ErrInvalidSignature},
+		{"", makeJwt(`{"alg":"none"}`, `{"exp":1699913600}`, unsigned), strings.Join([]string{
+			"header:",
+			"  alg: none",
+			"claims:",
+			"  exp: 1699913600 (Mon, 13 Nov 2023 22:13:20 UTC, expired a day ago)",
+			"signature: not checked",
+			"warnings:",
+			"  alg is none, the token isn't signed",
+			"  the token expired a day ago",
+		}, "\n"), nil},
+		{"", makeJwt(`{"alg":"HS256"}`, `{"nbf":1700007200,"scope":["a","b"]}`, unsigned), strings.Join([]string{
+			"header:",
+			"  alg: HS256",
+			"claims:",
+			"  nbf: 1700007200 (Wed, 15 Nov 2023 00:13:20 UTC, becomes valid in 2 hours)",
+			`  scope: ["a","b"]`,
+			"signature: not checked",
+			"warnings:",
+			"  the token isn't valid yet, it becomes valid in 2 hours",
+		}, "\n"), nil},
+		{"", "a.b.c", "", ErrNotAJWT},
+		{"", "hello", "", ErrNotAJWT},
+		{"", makeJwt(`{"typ":"JWT"}`, `{}`, unsigned), "", ErrNotAJWT},
+	}
+
+	for i, tt := range tests {
+		jwt, _ := NewJwt(tt.secret, nil, time.UTC, fixedClock)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := jwt.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestJwtVerifyPublicKeys(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	rsaDer, _ := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	ecDer, _ := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	pemKeys := append(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rsaDer}), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: ecDer})...)
+
+	enc := base64.RawURLEncoding
+	jwks := fmt.Sprintf(`{"keys":[{"kty":"RSA","kid":"r1","n":"%s","e":"AQAB"},{"kty":"EC","kid":"e1","crv":"P-256","x":"%s","y":"%s"}]}`,
+		enc.EncodeToString(rsaKey.N.Bytes()), enc.EncodeToString(ecKey.X.FillBytes(make([]byte, 32))), enc.EncodeToString(ecKey.Y.FillBytes(make([]byte, 32))))
+
+	rs256 := func(data []byte) []byte {
+		digest := sha256.Sum256(data)
+		sig, _ := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+		return sig
+	}
+	es256 := func(key *ecdsa.PrivateKey) func([]byte) []byte {
+		return func(data []byte) []byte {
+			digest := sha256.Sum256(data)
+			r, s, _ := ecdsa.Sign(rand.Reader, key, digest[:])
+			return append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		key   []byte
+		token string
+		out   string
+		err   error
+	}{
+		{"pem rs256", pemKeys, makeJwt(`{"alg":"RS256"}`, `{}`, rs256), "signature: valid", nil},
+		{"pem es256", pemKeys, makeJwt(`{"alg":"ES256"}`, `{}`, es256(ecKey)), "signature: valid", nil},
+		{"pem es256 other key", pemKeys, makeJwt(`{"alg":"ES256"}`, `{}`, es256(otherKey)), "signature: invalid", ErrInvalidSignature},
+		{"jwks rs256", []byte(jwks), makeJwt(`{"alg":"RS256","kid":"r1"}`, `{}`, rs256), "signature: valid", nil},
+		{"jwks es256", []byte(jwks), makeJwt(`{"alg":"ES256","kid":"e1"}`, `{}`, es256(ecKey)), "signature: valid", nil},
+		{"jwks wrong kid", []byte(jwks), makeJwt(`{"alg":"ES256","kid":"r1"}`, `{}`, es256(ecKey)), "signature: not checked (" + ErrUnsupportedAlg.Error() + ")", nil},
+		{"jwks hs256", []byte(jwks), makeJwt(`{"alg":"HS256"}`, `{}`, hs256("x")), "signature: not checked", nil},
+	}
+
+	for i, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			jwt, err := NewJwt("", tt.key, time.UTC, fixedClock)
+			if err != nil {
+				t.Fatalf("Case %d: unexpected error `%s`", i, err)
+			}
+			got, err := jwt.DoFromMachine(tt.token)
+			if !strings.Contains(got, tt.out) {
+				t.Errorf("Case %d: want `%s` in `%s`", i, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: want `%t` ; got `%t`", i, tt.err, err)
+			}
+		})
+	}
+
+	if _, err := NewJwt("", []byte("not a key"), nil, nil); err != ErrBadKey {
+		t.Errorf("want `%t` ; got `%t`", ErrBadKey, err)
+	}
+}
@@ -0,0 +1,462 @@
+package parsers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var ErrNotMoney error = errors.New("Not an amount of money, ie: $1,234.56, 1.2k EUR or two million dollars")
+var ErrUnknownCurrency error = errors.New("Unknown currency, use its ISO 4217 code (ie: USD, EUR, JPY)")
+var ErrUnknownLocale error = errors.New("Unknown locale")
+var ErrNoRate error = errors.New("No exchange rate")
+var ErrBadRate error = errors.New("Can't read exchange rate")
+
+// currency describes an ISO 4217 currency, the exponent is the number of
+// digits after the decimal point (ie: 2 for cents, 0 for yen)
+type currency struct {
+	symbol   string
+	exponent int
+	names    []string
+}
+
+// currencies are the ISO 4217 currencies we know how to write, the exponents
+// come from the standard's list of minor units
+var currencies = map[string]currency{
+	"AED": {"AED", 2, []string{"dirham", "dirhams"}},
+	"ARS": {"ARS", 2, nil},
+	"AUD": {"A$", 2, []string{"australian dollar", "australian dollars"}},
+	"BHD": {"BHD", 3, []string{"bahraini dinar", "bahraini dinars"}},
+	"BRL": {"R$", 2, []string{"real", "reais"}},
+	"CAD": {"CA$", 2, []string{"canadian dollar", "canadian dollars"}},
+	"CHF": {"CHF", 2, []string{"franc", "francs", "swiss franc", "swiss francs"}},
+	"CLF": {"CLF", 4, nil},
+	"CLP": {"CLP", 0, []string{"chilean peso", "chilean pesos"}},
+	"CNY": {"CN¥", 2, []string{"yuan", "renminbi"}},
+	"CZK": {"Kč", 2, []string{"koruna", "korunas"}},
+	"DKK": {"DKK", 2, []string{"danish krone", "danish kroner"}},
+	"EUR": {"€", 2, []string{"euro", "euros"}},
+	"GBP": {"£", 2, []string{"pound", "pounds", "quid"}},
+	"HKD": {"HK$", 2, []string{"hong kong dollar", "hong kong dollars"}},
+	"HUF": {"Ft", 2, []string{"forint", "forints"}},
+	"IDR": {"Rp", 2, []string{"rupiah", "rupiahs"}},
+	"ILS": {"₪", 2, []string{"shekel", "shekels"}},
+	"INR": {"₹", 2, []string{"rupee", "rupees"}},
+	"ISK": {"ISK", 0, []string{"icelandic krona", "icelandic kronur"}},
+	"JOD": {"JOD", 3, []string{"jordanian dinar", "jordanian dinars"}},
+	"JPY": {"¥", 0, []string{"yen"}},
+	"KRW": {"₩", 0, []string{"won"}},
+	"KWD": {"KWD", 3, []string{"kuwaiti dinar", "kuwaiti dinars"}},
+	"MXN": {"MX$", 2, []string{"peso", "pesos", "mexican peso", "mexican pesos"}},
+	"NOK": {"NOK", 2, []string{"norwegian krone", "norwegian kroner"}},
+	"NZD": {"NZ$", 2, []string{"new zealand dollar", "new zealand dollars"}},
+	"OMR": {"OMR", 3, []string{"omani rial", "omani rials"}},
+	"PLN": {"zł", 2, []string{"zloty", "zlotys"}},
+	"RUB": {"₽", 2, []string{"ruble", "rubles", "rouble", "roubles"}},
+	"SEK": {"SEK", 2, []string{"swedish krona", "swedish kronor"}},
+	"SGD": {"S$", 2, []string{"singapore dollar", "singapore dollars"}},
+	"THB": {"฿", 2, []string{"baht"}},
+	"TND": {"TND", 3, []string{"tunisian dinar", "tunisian dinars"}},
+	"TRY": {"₺", 2, []string{"lira", "liras"}},
+	"TWD": {"NT$", 2, []string{"new taiwan dollar", "new taiwan dollars"}},
+	"UAH": {"₴", 2, []string{"hryvnia", "hryvnias"}},
+	"USD": {"$", 2, []string{"dollar", "dollars", "buck", "bucks"}},
+	"VND": {"₫", 0, []string{"dong"}},
+	"ZAR": {"R", 2, []string{"rand"}},
+}
+
+// moneyLocale is how amounts are written in a language
+type moneyLocale struct {
+	group   string
+	decimal string
+	// pattern is where the symbol (¤) goes around the amount (#)
+	pattern string
+}
+
+// moneyLocales are the languages we know how to write amounts in, a locale
+// with a region (ie: de-AT or de_AT) is written like its language
+var moneyLocales = map[string]moneyLocale{
+	"de": {".", ",", "# ¤"},
+	"en": {",", ".", "¤#"},
+	"es": {".", ",", "# ¤"},
+	"fr": {" ", ",", "# ¤"},
+	"it": {".", ",", "# ¤"},
+	"ja": {",", ".", "¤#"},
+	"nl": {".", ",", "¤ #"},
+	"pl": {" ", ",", "# ¤"},
+	"pt": {".", ",", "# ¤"},
+	"sv": {" ", ",", "# ¤"},
+	"zh": {",", ".", "¤#"},
+}
+
+// Money writes amounts of money, the machine side of things is an integer
+// amount of the currency's minor unit (ie: cents) and the human side is the
+// amount as it's written in the locale, ie: 123456 <-> $1,234.56 or, in
+// German, 1.234,56 €
+//
+// Amounts written by humans can use a symbol, a code or the currency's name
+// and can be compact (1.2k) or have words in them (2.5 million dollars, two
+// million dollars)
+//
+// Converting between currencies uses the rates given, there's no looking
+// them up online
+type Money struct {
+	// currency is the code of the amount's currency
+	currency string
+	// to is the code of the currency to convert into, an empty string means the
+	// amount is left in its own currency
+	to     string
+	locale moneyLocale
+	rates  *ExchangeRates
+}
+
+// NewMoney constructs a Money parser, the currency defaults to USD and the
+// locale to en. Rates can be nil when nothing has to be converted
+func NewMoney(code, to, locale string, rates *ExchangeRates) (*Money, error) {
+	if code == "" {
+		code = "USD"
+	}
+	code = strings.ToUpper(code)
+	if _, ok := currencies[code]; !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownCurrency, code)
+	}
+
+	to = strings.ToUpper(to)
+	if _, ok := currencies[to]; to != "" && !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownCurrency, to)
+	}
+
+	if locale == "" {
+		locale = "en"
+	}
+	lang := strings.ToLower(regexp.MustCompile(`[-_].*$`).ReplaceAllString(locale, ""))
+	l, ok := moneyLocales[lang]
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownLocale, locale)
+	}
+
+	if rates == nil {
+		rates = NewExchangeRates()
+	}
+	return &Money{currency: code, to: to, locale: l, rates: rates}, nil
+}
+
+// CanParseFromMachine determines if the input is a whole number of minor
+// units
+func (m *Money) CanParseFromMachine(s string) (bool, error) {
+	if !regexp.MustCompile(`^-?[0-9]+$`).MatchString(strings.TrimSpace(s)) {
+		return false, ErrNotMoney
+	}
+	return true, nil
+}
+
+// CanParseIntoMachine determines if the input is an amount of money written
+// by a human
+func (m *Money) CanParseIntoMachine(s string) (bool, error) {
+	if _, _, err := m.parseAmount(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DoFromMachine writes the minor units as an amount in the locale
+func (m *Money) DoFromMachine(s string) (string, error) {
+	if ok, err := m.CanParseFromMachine(s); !ok {
+		return "", err
+	}
+
+	minor, _ := new(big.Rat).SetString(strings.TrimSpace(s))
+	amount := new(big.Rat).Quo(minor, pow10Rat(currencies[m.currency].exponent))
+
+	code := m.currency
+	if m.to != "" {
+		converted, err := m.rates.Convert(amount, code, m.to)
+		if err != nil {
+			return "", err
+		}
+		amount, code = converted, m.to
+	}
+
+	return m.format(amount, code), nil
+}
+
+// DoIntoMachine gives back the minor units of the amount, converted when a
+// currency to convert into was given
+func (m *Money) DoIntoMachine(s string) (string, error) {
+	amount, code, err := m.parseAmount(s)
+	if err != nil {
+		return "", err
+	}
+
+	if m.to != "" {
+		if amount, err = m.rates.Convert(amount, code, m.to); err != nil {
+			return "", err
+		}
+		code = m.to
+	}
+
+	minor := new(big.Rat).Mul(amount, pow10Rat(currencies[code].exponent))
+	return minor.FloatString(0), nil
+}
+
+// Lossless is true unless converting into another currency, amounts are
+// written down to the minor unit but converted ones are read back in the
+// currency they were converted into
+func (m *Money) Lossless() bool {
+	return m.to == ""
+}
+
+// format writes the amount with the currency's symbol, rounded to its minor
+// unit and grouped the way the locale does it
+func (m *Money) format(amount *big.Rat, code string) string {
+	c := currencies[code]
+	digits := amount.FloatString(c.exponent)
+
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	whole, fraction := digits, ""
+	if i := strings.Index(digits, "."); i >= 0 {
+		whole, fraction = digits[:i], digits[i+1:]
+	}
+
+	grouped, _ := NewNumberGroup().DoFromMachine(whole)
+	number := strings.ReplaceAll(grouped, ",", m.locale.group)
+	if fraction != "" {
+		number += m.locale.decimal + fraction
+	}
+
+	// Symbols made of letters need a space to not run into the number
+	pattern := m.locale.pattern
+	if pattern == "¤#" && regexp.MustCompile(`[A-Za-z]$`).MatchString(c.symbol) {
+		pattern = "¤ #"
+	}
+	return sign + strings.NewReplacer("¤", c.symbol, "#", number).Replace(pattern)
+}
+
+// parseAmount reads an amount written by a human and gives it back along with
+// the code of its currency, which is the parser's one when it isn't given
+func (m *Money) parseAmount(s string) (*big.Rat, string, error) {
+	s = strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(s, "-") {
+		negative, s = true, strings.TrimSpace(s[1:])
+	}
+
+	rest, code := m.splitCurrency(s)
+	if rest == "" {
+		return nil, "", ErrNotMoney
+	}
+	if strings.HasPrefix(rest, "-") {
+		negative, rest = true, strings.TrimSpace(rest[1:])
+	}
+
+	amount, err := m.parseNumber(rest)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if negative {
+		amount.Neg(amount)
+	}
+	return amount, code, nil
+}
+
+// splitCurrency takes the currency out of the input, it can be a code (USD),
+// a symbol ($, US$, €) or a name (dollars). A symbol or name that's shared
+// with the parser's currency (ie: $ when it's CAD) is taken as being that one
+func (m *Money) splitCurrency(s string) (string, string) {
+	lower := strings.ToLower(s)
+
+	// Longest first so US$ and CA$ aren't read as $
+	type candidate struct {
+		text string
+		code string
+	}
+	var candidates []candidate
+	for code, c := range currencies {
+		candidates = append(candidates, candidate{strings.ToLower(code), code}, candidate{strings.ToLower(c.symbol), code})
+		for _, name := range c.names {
+			candidates = append(candidates, candidate{name, code})
+		}
+	}
+	candidates = append(candidates, candidate{"us$", "USD"})
+	sort.Slice(candidates, func(i, j int) bool {
+		if len(candidates[i].text) != len(candidates[j].text) {
+			return len(candidates[i].text) > len(candidates[j].text)
+		}
+		return candidates[i].code < candidates[j].code
+	})
+
+	own := currencies[m.currency]
+	for _, c := range candidates {
+		// A name or code has to be a word of its own, ie: the `R` in `5 rand`
+		// isn't the rand's symbol
+		var rest, next string
+		switch {
+		case strings.HasPrefix(lower, c.text):
+			rest = s[len(c.text):]
+			next = regexp.MustCompile(`^.?`).FindString(rest)
+		case strings.HasSuffix(lower, c.text):
+			rest = s[:len(s)-len(c.text)]
+			next = regexp.MustCompile(`.?$`).FindString(rest)
+		default:
+			continue
+		}
+		if regexp.MustCompile(`^[a-z]`).MatchString(c.text) && regexp.MustCompile(`[A-Za-z]`).MatchString(next) {
+			continue
+		}
+
+		code := c.code
+		if c.text != strings.ToLower(code) && (strings.HasSuffix(strings.ToLower(own.symbol), c.text) || contains(own.names, c.text)) {
+			code = m.currency
+		}
+		return strings.TrimSpace(rest), code
+	}
+
+	return s, m.currency
+}
+
+// parseNumber reads the amount, which can be written the way the locale does
+// it (1.234,56 in German), be compact (1.2k) or have words in it (2.5 million,
+// two million)
+func (m *Money) parseNumber(s string) (*big.Rat, error) {
+	s = strings.TrimSpace(s)
+
+	compact := NewNumberCompact("short", 0)
+	if num, power, err := compact.getInputComponents(m.delocalize(s)); err == nil {
+		return num.Mul(num, pow10Rat(power)), nil
+	}
+
+	if match := regexp.MustCompile(`^(\S+)\s+([a-z]+)$`).FindStringSubmatch(strings.ToLower(s)); match != nil {
+		for _, v := range NewNumberWord().trans {
+			if v.name != match[2] {
+				continue
+			}
+			if num, ok := new(big.Rat).SetString(m.delocalize(match[1])); ok {
+				return num.Mul(num, pow10Rat(v.powers)), nil
+			}
+		}
+	}
+
+	if plain := m.delocalize(s); regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`).MatchString(plain) {
+		num, _ := new(big.Rat).SetString(plain)
+		return num, nil
+	}
+
+	words := strings.Fields(strings.ReplaceAll(strings.ToLower(s), "-", " "))
+	if n, err := parseSpelledNumber(words); err == nil {
+		return new(big.Rat).SetFloat64(n), nil
+	}
+
+	return nil, ErrNotMoney
+}
+
+// delocalize takes the grouping out of the number and writes its decimals
+// with a dot, ie: 1.234,56 -> 1234.56 in German. When both a dot and a comma
+// are there the last one is the decimal point whatever the locale
+func (m *Money) delocalize(s string) string {
+	group, decimal := m.locale.group, m.locale.decimal
+	if dot, comma := strings.LastIndex(s, "."), strings.LastIndex(s, ","); dot >= 0 && comma >= 0 {
+		group, decimal = ",", "."
+		if comma > dot {
+			group, decimal = ".", ","
+		}
+	}
+
+	s = strings.ReplaceAll(s, group, "")
+	if group == " " {
+		// Typeset amounts group with (narrow) no-break spaces
+		s = strings.NewReplacer("\u00a0", "", "\u202f", "").Replace(s)
+	}
+	return strings.Replace(s, decimal, ".", 1)
+}
+
+// ExchangeRates knows how much a unit of a currency is worth in others, rates
+// are kept both ways and a conversion can go through other currencies (ie:
+// CHF -> EUR -> USD)
+type ExchangeRates struct {
+	rates map[string]map[string]*big.Rat
+}
+
+// NewExchangeRates constructs an empty set of rates
+func NewExchangeRates() *ExchangeRates {
+	return &ExchangeRates{rates: map[string]map[string]*big.Rat{}}
+}
+
+// Read adds the rates in the text, one per line:
+// 	EUR = 1.0842 USD
+// Empty lines and lines starting with # are skipped, a later rate replaces an
+// earlier one for the same currencies
+func (e *ExchangeRates) Read(text string) error {
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		m := regexp.MustCompile(`^(?:1\s+)?([A-Za-z]{3})\s*=\s*([0-9.]+)\s*([A-Za-z]{3})$`).FindStringSubmatch(line)
+		if m == nil {
+			return fmt.Errorf("%w: line %d '%s'", ErrBadRate, n, line)
+		}
+		rate, ok := new(big.Rat).SetString(m[2])
+		if !ok || rate.Sign() == 0 {
+			return fmt.Errorf("%w: line %d '%s'", ErrBadRate, n, line)
+		}
+		e.Set(strings.ToUpper(m[1]), strings.ToUpper(m[3]), rate)
+	}
+	return nil
+}
+
+// Set records that a unit of `from` is worth `rate` of `to`
+func (e *ExchangeRates) Set(from, to string, rate *big.Rat) {
+	for _, code := range []string{from, to} {
+		if e.rates[code] == nil {
+			e.rates[code] = map[string]*big.Rat{}
+		}
+	}
+	e.rates[from][to] = rate
+	e.rates[to][from] = new(big.Rat).Inv(rate)
+}
+
+// Convert gives back the amount in the other currency, going through as few
+// other currencies as possible when there's no direct rate
+func (e *ExchangeRates) Convert(amount *big.Rat, from, to string) (*big.Rat, error) {
+	if from == to {
+		return amount, nil
+	}
+
+	// Breadth first so the shortest chain of rates is used
+	factors := map[string]*big.Rat{from: big.NewRat(1, 1)}
+	queue := []string{from}
+	for len(queue) > 0 {
+		code := queue[0]
+		queue = queue[1:]
+
+		next := make([]string, 0, len(e.rates[code]))
+		for other := range e.rates[code] {
+			next = append(next, other)
+		}
+		sort.Strings(next)
+
+		for _, other := range next {
+			if _, seen := factors[other]; seen {
+				continue
+			}
+			factors[other] = new(big.Rat).Mul(factors[code], e.rates[code][other])
+			if other == to {
+				return new(big.Rat).Mul(amount, factors[other]), nil
+			}
+			queue = append(queue, other)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: '%s' into '%s'", ErrNoRate, from, to)
+}
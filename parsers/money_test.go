@@ -0,0 +1,131 @@
+package parsers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMoneyDoFromMachine(t *testing.T) {
+	rates := NewExchangeRates()
+	rates.Read("EUR = 1.08 USD\nUSD = 0.79 GBP")
+
+	tests := []struct {
+		currency string
+		to       string
+		locale   string
+		in       string
+		out      string
+		err      error
+	}{
+		{"", "", "", "123456", "$1,234.56", nil},
+		{"EUR", "", "de", "123456", "1.234,56 €", nil},
+		{"EUR", "", "de-AT", "123456", "1.234,56 €", nil},
+		{"EUR", "", "fr", "123456789", "1 234 567,89 €", nil},
+		{"EUR", "", "nl", "123456", "€ 1.234,56", nil},
+		{"USD", "", "en", "-5", "-$0.05", nil},
+		{"jpy", "", "", "123456", "¥123,456", nil},
+		{"BHD", "", "", "123456", "BHD 123.456", nil},
+		{"CLF", "", "", "12345", "CLF 1.2345", nil},
+		{"CHF", "", "de", "100", "1,00 CHF", nil},
+		// Conversions, GBP goes through USD
+		{"EUR", "USD", "", "100", "$1.08", nil},
+		{"USD", "EUR", "", "108", "€1.00", nil},
+		{"EUR", "GBP", "", "123456", "£1,053.33", nil},
+		{"EUR", "JPY", "", "100", "", ErrNoRate},
+		{"", "", "", "12.50", "", ErrNotMoney},
+	}
+
+	for i, tt := range tests {
+		money, _ := NewMoney(tt.currency, tt.to, tt.locale, rates)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := money.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestMoneyDoIntoMachine(t *testing.T) {
+	rates := NewExchangeRates()
+	rates.Read("EUR = 1.08 USD")
+
+	tests := []struct {
+		currency string
+		to       string
+		locale   string
+		in       string
+		out      string
+		err      error
+	}{
+		{"", "", "", "$1,234.56", "123456", nil},
+		{"", "", "", "$1.2k", "120000", nil},
+		{"", "", "", "two million dollars", "200000000", nil},
+		{"", "", "", "2.5 million dollars", "250000000", nil},
+		{"", "", "", "1.2K EUR", "120000", nil},
+		{"", "", "", "USD 12", "1200", nil},
+		{"", "", "", "¥500", "500", nil},
+		{"", "", "", "-$3.50", "-350", nil},
+		{"", "", "", "1.5 bucks", "150", nil},
+		{"", "", "", "0.001 BHD", "1", nil},
+		{"", "", "de", "1.234,56 €", "123456", nil},
+		{"", "", "fr", "1 234,56 €", "123456", nil},
+		// Both separators, the last one is the decimal point
+		{"", "", "en", "1.234,56 €", "123456", nil},
+		// $ is the parser's currency when that one uses it too
+		{"CAD", "", "", "$5", "500", nil},
+		{"", "EUR", "", "$108", "10000", nil},
+		{"", "JPY", "", "$1", "", ErrNoRate},
+		{"", "", "", "lots of money", "", ErrNotMoney},
+		{"", "", "", "$", "", ErrNotMoney},
+	}
+
+	for i, tt := range tests {
+		money, _ := NewMoney(tt.currency, tt.to, tt.locale, rates)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := money.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestNewMoney(t *testing.T) {
+	tests := []struct {
+		currency string
+		to       string
+		locale   string
+		err      error
+	}{
+		{"XYZ", "", "", ErrUnknownCurrency},
+		{"", "XYZ", "", ErrUnknownCurrency},
+		{"", "", "tlh", ErrUnknownLocale},
+	}
+
+	for i, tt := range tests {
+		if _, err := NewMoney(tt.currency, tt.to, tt.locale, nil); !errors.Is(err, tt.err) {
+			t.Errorf("Error Case %d: want `%v` ; got `%v`", i, tt.err, err)
+		}
+	}
+}
+
+func TestExchangeRatesRead(t *testing.T) {
+	rates := NewExchangeRates()
+	if err := rates.Read("# comment\n\n1 EUR = 1.08 USD\nchf = 1.05 eur\n"); err != nil {
+		t.Fatalf("want no error ; got `%v`", err)
+	}
+
+	if err := rates.Read("EUR is 1.08 USD"); !errors.Is(err, ErrBadRate) {
+		t.Errorf("want `%v` ; got `%v`", ErrBadRate, err)
+	}
+	if err := rates.Read("EUR = 0 USD"); !errors.Is(err, ErrBadRate) {
+		t.Errorf("want `%v` ; got `%v`", ErrBadRate, err)
+	}
+}
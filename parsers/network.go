@@ -0,0 +1,189 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"regexp"
+	"strings"
+)
+
+var ErrNotAnAddress error = errors.New("Not an IP address, ie: 10.0.0.1, 10.0.0.0/22, 255.255.252.0 or 2001:db8::1")
+
+// Network converts IP addresses, networks and netmasks. The machine side of
+// things is what programs take: integers, full IPv6 addresses, prefix
+// lengths. The human side is what people write, ie:
+// 	167772160                         <-> 10.0.0.0
+// 	2001:0db8:0000:...:0001           <-> 2001:db8::1
+// 	255.255.252.0                     <-> /22
+// 	10.0.0.0/24                       <-  10.0.0.0 netmask 255.255.255.0
+//
+// Networks (ie: 10.0.0.0/22) are explained: network, netmask, broadcast,
+// first and last usable hosts and how many hosts fit
+type Network struct{}
+
+// NewNetwork constructs a Network parser
+func NewNetwork() *Network {
+	return &Network{}
+}
+
+// CanParseFromMachine determines if the input is an integer that fits in an
+// IPv6 address, a network, a netmask or an IPv6 address
+func (n *Network) CanParseFromMachine(s string) (bool, error) {
+	if _, err := n.DoFromMachine(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CanParseIntoMachine determines if the input is an address, a prefix length
+// or an address followed by its netmask
+func (n *Network) CanParseIntoMachine(s string) (bool, error) {
+	if _, err := n.DoIntoMachine(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DoFromMachine writes integers as addresses (IPv4 when they fit in 32 bits),
+// netmasks as prefix lengths, IPv6 addresses compressed and explains networks
+func (n *Network) DoFromMachine(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	if isMachineNumber(s) {
+		i, _ := new(big.Int).SetString(s, 10)
+		if i.BitLen() > 128 {
+			return "", ErrTooLarge
+		}
+
+		size := net.IPv6len
+		if i.BitLen() <= 32 {
+			size = net.IPv4len
+		}
+		return net.IP(i.FillBytes(make([]byte, size))).String(), nil
+	}
+
+	if strings.Contains(s, "/") {
+		return describeNetwork(s)
+	}
+
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return "", ErrNotAnAddress
+	}
+
+	if ip.To4() == nil {
+		return ip.String(), nil
+	}
+
+	if ones, ok := netmaskSize(ip); ok {
+		return fmt.Sprintf("/%d", ones), nil
+	}
+
+	return "", ErrNotAnAddress
+}
+
+// DoIntoMachine writes IPv4 addresses as integers, IPv6 addresses in full,
+// prefix lengths as netmasks and addresses with a netmask as networks
+func (n *Network) DoIntoMachine(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	if match := regexp.MustCompile(`^/([0-9]{1,2})$`).FindStringSubmatch(s); match != nil {
+		var ones int
+		fmt.Sscan(match[1], &ones)
+		if ones > 32 {
+			return "", ErrNotAnAddress
+		}
+		return net.IP(net.CIDRMask(ones, 32)).String(), nil
+	}
+
+	if match := regexp.MustCompile(`^(\S+)\s+(?:netmask\s+|mask\s+)?(\S+)$`).FindStringSubmatch(s); match != nil {
+		ip, mask := net.ParseIP(match[1]).To4(), net.ParseIP(match[2]).To4()
+		if ip == nil || mask == nil {
+			return "", ErrNotAnAddress
+		}
+		ones, ok := netmaskSize(mask)
+		if !ok {
+			return "", ErrNotAnAddress
+		}
+		return fmt.Sprintf("%s/%d", ip, ones), nil
+	}
+
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return "", ErrNotAnAddress
+	}
+
+	if v4 := ip.To4(); v4 != nil && !strings.Contains(s, ":") {
+		return new(big.Int).SetBytes(v4).String(), nil
+	}
+
+	return expandIPv6(ip), nil
+}
+
+// Lossless is false, networks are described and IPv6 addresses are read
+// into their long form
+func (n *Network) Lossless() bool {
+	return false
+}
+
+// describeNetwork explains the network one property per line
+func describeNetwork(s string) (string, error) {
+	_, network, err := net.ParseCIDR(s)
+	if err != nil {
+		return "", ErrNotAnAddress
+	}
+
+	ones, bits := network.Mask.Size()
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	first := new(big.Int).SetBytes(network.IP)
+	last := new(big.Int).Add(first, size)
+	last.Sub(last, big.NewInt(1))
+
+	toIP := func(i *big.Int) string {
+		return net.IP(i.FillBytes(make([]byte, len(network.IP)))).String()
+	}
+
+	lines := [][2]string{{"network", network.String()}}
+	if bits == 32 {
+		lines = append(lines, [2]string{"netmask", net.IP(network.Mask).String()})
+	}
+
+	// The network and broadcast addresses can't be used by hosts, except in
+	// point to point links (/31) and single hosts (/32). IPv6 has no broadcast
+	hosts := new(big.Int).Set(size)
+	firstHost, lastHost := first, last
+	if bits == 32 && ones < 31 {
+		lines = append(lines, [2]string{"broadcast", toIP(last)})
+		firstHost = new(big.Int).Add(first, big.NewInt(1))
+		lastHost = new(big.Int).Sub(last, big.NewInt(1))
+		hosts.Sub(hosts, big.NewInt(2))
+	}
+
+	lines = append(lines,
+		[2]string{"first", toIP(firstHost)},
+		[2]string{"last", toIP(lastHost)},
+		[2]string{"hosts", strings.Replace(groupDigits(hosts.String(), 3), "_", ",", -1)},
+	)
+
+	return alignLines(lines), nil
+}
+
+// netmaskSize gives back the prefix length of an IPv4 netmask, masks that
+// aren't contiguous ones followed by zeros (or that are all zeros) aren't
+// netmasks
+func netmaskSize(ip net.IP) (int, bool) {
+	ones, bits := net.IPMask(ip.To4()).Size()
+	return ones, bits != 0 && ones != 0
+}
+
+// expandIPv6 writes all 8 groups of the address with all their digits
+func expandIPv6(ip net.IP) string {
+	ip = ip.To16()
+	groups := make([]string, 8)
+	for i := range groups {
+		groups[i] = fmt.Sprintf("%02x%02x", ip[i*2], ip[i*2+1])
+	}
+	return strings.Join(groups, ":")
+}
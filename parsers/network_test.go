@@ -0,0 +1,80 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestNetworkDoFromMachine(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+		err error
+	}{
+		{"167772160", "10.0.0.0", nil},
+		{"0", "0.0.0.0", nil},
+		{"4294967295", "255.255.255.255", nil},
+		{"4294967296", "::1:0:0", nil},
+		{"42540766411282592856903984951653826561", "2001:db8::1", nil},
+		{"340282366920938463463374607431768211456", "", ErrTooLarge},
+		{"255.255.252.0", "/22", nil},
+		{"255.255.255.255", "/32", nil},
+		{"255.0.255.0", "", ErrNotAnAddress},
+		{"10.0.0.1", "", ErrNotAnAddress},
+		{"2001:0db8:0000:0000:0000:0000:0000:0001", "2001:db8::1", nil},
+		{"10.0.0.0/22", "network:   10.0.0.0/22\nnetmask:   255.255.252.0\nbroadcast: 10.0.3.255\nfirst:     10.0.0.1\nlast:      10.0.3.254\nhosts:     1,022", nil},
+		{"10.0.1.7/22", "network:   10.0.0.0/22\nnetmask:   255.255.252.0\nbroadcast: 10.0.3.255\nfirst:     10.0.0.1\nlast:      10.0.3.254\nhosts:     1,022", nil},
+		{"192.168.1.0/31", "network:   192.168.1.0/31\nnetmask:   255.255.255.254\nfirst:     192.168.1.0\nlast:      192.168.1.1\nhosts:     2", nil},
+		{"192.168.1.1/32", "network:   192.168.1.1/32\nnetmask:   255.255.255.255\nfirst:     192.168.1.1\nlast:      192.168.1.1\nhosts:     1", nil},
+		{"2001:db8::/64", "network:   2001:db8::/64\nfirst:     2001:db8::\nlast:      2001:db8::ffff:ffff:ffff:ffff\nhosts:     18,446,744,073,709,551,616", nil},
+		{"10.0.0.0/33", "", ErrNotAnAddress},
+		{"hello", "", ErrNotAnAddress},
+	}
+
+	network := NewNetwork()
+	for i, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := network.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestNetworkDoIntoMachine(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+		err error
+	}{
+		{"10.0.0.0", "167772160", nil},
+		{"255.255.255.255", "4294967295", nil},
+		{"2001:db8::1", "2001:0db8:0000:0000:0000:0000:0000:0001", nil},
+		{"::ffff:10.0.0.1", "0000:0000:0000:0000:0000:ffff:0a00:0001", nil},
+		{"/22", "255.255.252.0", nil},
+		{"/0", "0.0.0.0", nil},
+		{"/33", "", ErrNotAnAddress},
+		{"10.0.0.0 netmask 255.255.255.0", "10.0.0.0/24", nil},
+		{"10.0.0.5 mask 255.255.0.0", "10.0.0.5/16", nil},
+		{"10.0.0.0 255.255.252.0", "10.0.0.0/22", nil},
+		{"10.0.0.0 netmask 255.0.255.0", "", ErrNotAnAddress},
+		{"10.0.0.0 netmask banana", "", ErrNotAnAddress},
+		{"167772160", "", ErrNotAnAddress},
+	}
+
+	network := NewNetwork()
+	for i, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := network.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
@@ -89,3 +89,8 @@ func (n *NumberGroup) DoFromMachine(s string) (string, error) {
 func (n *NumberGroup) DoIntoMachine(s string) (string, error) {
 	return strings.Replace(s, ",", "", -1), nil
 }
+
+// Lossless is true, grouping only adds the separators
+func (n *NumberGroup) Lossless() bool {
+	return true
+}
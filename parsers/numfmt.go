@@ -0,0 +1,407 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidNumber error = errors.New("invalid number")
+var ErrRejectingSuffix error = errors.New("rejecting suffix in input (consider using --from)")
+var ErrInvalidSuffix error = errors.New("invalid suffix in input")
+var ErrMissingISuffix error = errors.New("missing 'i' suffix in input (e.g Ki/Mi/Gi)")
+var ErrValueTooLarge error = errors.New("value too large to be converted")
+var ErrBadNumfmtOption error = errors.New("invalid numfmt option")
+var ErrInvalidInput error = errors.New("invalid input")
+
+// numfmtPrefixes are the single letter suffixes numfmt understands, in order
+// of their power
+const numfmtPrefixes = "KMGTPEZY"
+
+// NumfmtOptions are the options of the GNU `numfmt` tool that we support, see
+// 	https://www.gnu.org/software/coreutils/manual/html_node/numfmt-invocation.html
+type NumfmtOptions struct {
+	// From is the unit of the input: none, auto, si, iec, iec-i
+	From string
+	// To is the unit of the output: none, si, iec, iec-i
+	To string
+	// Suffix is added to the output and is optional in the input
+	Suffix string
+	// Padding is the width of the output, negative numbers left align. When 0
+	// the fields that had leading whitespace keep their original width
+	Padding int
+	// Round is how to round when scaling: up, down, from-zero, towards-zero,
+	// nearest
+	Round string
+	// Field are the fields to convert, ie: 1, 2-4, -3, 5-, 1,3
+	Field string
+	// Header is how many lines at the top are printed without converting them
+	Header int
+	// Invalid is what to do with input that isn't a number: abort, fail, warn,
+	// ignore
+	Invalid string
+}
+
+// Numfmt is a compatibility layer for the GNU `numfmt` tool. Unlike the other
+// parsers the direction is chosen by the `From` and `To` options so both the
+// `FromMachine` and `IntoMachine` methods do the same thing.
+//
+// The input can be multiple lines, each line is split in whitespace separated
+// fields and only the selected fields are converted
+type Numfmt struct {
+	opts   NumfmtOptions
+	fields func(int) bool
+	// warnings holds the messages for invalid input when Invalid is "warn" or
+	// "fail", they're gathered here since parsers don't print anything
+	warnings []string
+}
+
+// NewNumfmt constructs a Numfmt parser filling in numfmt's defaults for any
+// option that wasn't set
+func NewNumfmt(opts NumfmtOptions) (*Numfmt, error) {
+	defaults := []struct {
+		value   *string
+		def     string
+		allowed []string
+	}{
+		{&opts.From, "none", []string{"none", "auto", "si", "iec", "iec-i"}},
+		{&opts.To, "none", []string{"none", "si", "iec", "iec-i"}},
+		{&opts.Round, "from-zero", []string{"up", "down", "from-zero", "towards-zero", "nearest"}},
+		{&opts.Invalid, "abort", []string{"abort", "fail", "warn", "ignore"}},
+		{&opts.Field, "1", nil},
+	}
+
+	for _, d := range defaults {
+		if *d.value == "" {
+			*d.value = d.def
+		}
+		if d.allowed != nil && !contains(d.allowed, *d.value) {
+			return nil, fmt.Errorf("%w: '%s'", ErrBadNumfmtOption, *d.value)
+		}
+	}
+
+	fields, err := parseFieldSpec(opts.Field)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Numfmt{opts: opts, fields: fields}, nil
+}
+
+// Warnings gives back the messages for the invalid input found during the
+// last conversion
+func (n *Numfmt) Warnings() []string {
+	return n.warnings
+}
+
+// CanParseFromMachine determines if the whole input can be converted
+func (n *Numfmt) CanParseFromMachine(s string) (bool, error) {
+	if _, err := n.convert(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CanParseIntoMachine is the same as CanParseFromMachine, see `Numfmt`
+func (n *Numfmt) CanParseIntoMachine(s string) (bool, error) {
+	return n.CanParseFromMachine(s)
+}
+
+// DoFromMachine converts the selected fields of every line. On error, the
+// lines that were converted up until then are still given back (along with
+// the rest of the lines when Invalid is "fail")
+func (n *Numfmt) DoFromMachine(s string) (string, error) {
+	return n.convert(s)
+}
+
+// DoIntoMachine is the same as DoFromMachine, see `Numfmt`
+func (n *Numfmt) DoIntoMachine(s string) (string, error) {
+	return n.convert(s)
+}
+
+// Lossless is false, just like numfmt numbers are rounded to the unit
+func (n *Numfmt) Lossless() bool {
+	return false
+}
+
+func (n *Numfmt) convert(s string) (string, error) {
+	n.warnings = nil
+
+	var out []string
+	failed := 0
+	for i, line := range strings.Split(s, "\n") {
+		if i < n.opts.Header {
+			out = append(out, line)
+			continue
+		}
+
+		converted, err := n.convertLine(line)
+		if err != nil {
+			switch n.opts.Invalid {
+			case "abort":
+				return strings.Join(out, "\n"), err
+			case "fail":
+				failed++
+				n.warnings = append(n.warnings, err.Error())
+			case "warn":
+				n.warnings = append(n.warnings, err.Error())
+			}
+		}
+		out = append(out, converted)
+	}
+
+	// Like numfmt, every line is reported on its own and the conversion still
+	// fails, the error only counts them so that they aren't reported twice
+	if failed > 0 {
+		return strings.Join(out, "\n"), fmt.Errorf("%w: %d line(s) couldn't be converted", ErrInvalidInput, failed)
+	}
+	return strings.Join(out, "\n"), nil
+}
+
+// convertLine splits the line into fields the same way numfmt does: the first
+// whitespace character is the delimiter and any whitespace after that belongs
+// to the next field. This is what allows keeping columns aligned since a field
+// with leading whitespace is padded to its original width
+func (n *Numfmt) convertLine(line string) (string, error) {
+	var fields []string
+	rest := line
+	for len(rest) > 0 {
+		if len(fields) > 0 {
+			rest = rest[1:]
+		}
+		start := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsSpace(r) })
+		if start < 0 {
+			fields = append(fields, rest)
+			break
+		}
+		end := strings.IndexFunc(rest[start:], unicode.IsSpace)
+		if end < 0 {
+			end = len(rest) - start
+		}
+		fields = append(fields, rest[:start+end])
+		rest = rest[start+end:]
+	}
+
+	var lineErr error
+	for i, f := range fields {
+		value := strings.TrimLeftFunc(f, unicode.IsSpace)
+		if !n.fields(i+1) || value == "" {
+			continue
+		}
+
+		converted, err := n.convertNumber(value)
+		if err != nil {
+			if lineErr == nil {
+				lineErr = err
+			}
+			continue
+		}
+
+		switch {
+		case n.opts.Padding > 0:
+			converted = fmt.Sprintf("%*s", n.opts.Padding, converted)
+		case n.opts.Padding < 0:
+			converted = fmt.Sprintf("%-*s", -n.opts.Padding, converted)
+		case len(value) != len(f):
+			converted = fmt.Sprintf("%*s", len(f), converted)
+		}
+		fields[i] = converted
+	}
+
+	return strings.Join(fields, " "), lineErr
+}
+
+// convertNumber reads a single number in the `From` unit and writes it in the
+// `To` unit
+func (n *Numfmt) convertNumber(s string) (string, error) {
+	raw := s
+	if n.opts.Suffix != "" {
+		s = strings.TrimSuffix(s, n.opts.Suffix)
+	}
+
+	r := regexp.MustCompile(`^([-+]?[0-9]*\.?[0-9]+)(.*)$`)
+	match := r.FindStringSubmatch(s)
+	if match == nil {
+		return "", fmt.Errorf("%w: '%s'", ErrInvalidNumber, raw)
+	}
+
+	val, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return "", fmt.Errorf("%w: '%s'", ErrInvalidNumber, raw)
+	}
+
+	// Numbers without a suffix keep the precision they had when they aren't
+	// being scaled, ie: 1.50 stays 1.50
+	precision := 0
+	scale := big.NewInt(1)
+	if i := strings.Index(match[1], "."); i >= 0 {
+		precision = len(match[1]) - i - 1
+	}
+
+	if suffix := match[2]; suffix != "" {
+		power := strings.Index(numfmtPrefixes, suffix[:1]) + 1
+		binary := len(suffix) == 2 && suffix[1] == 'i'
+
+		switch {
+		case power == 0 || len(suffix) > 2 || (len(suffix) == 2 && !binary):
+			return "", fmt.Errorf("%w: '%s'", ErrInvalidSuffix, raw)
+		case n.opts.From == "none":
+			return "", fmt.Errorf("%w: '%s'", ErrRejectingSuffix, raw)
+		case binary && (n.opts.From == "si" || n.opts.From == "iec"):
+			return "", fmt.Errorf("%w: '%s'", ErrInvalidSuffix, raw)
+		case !binary && n.opts.From == "iec-i":
+			return "", fmt.Errorf("%w: '%s'", ErrMissingISuffix, raw)
+		}
+
+		base := 1000.0
+		if binary || n.opts.From == "iec" {
+			base = 1024.0
+		}
+		val = val * math.Pow(base, float64(power))
+		scale.Exp(big.NewInt(int64(base)), big.NewInt(int64(power)), nil)
+		precision = 0
+	}
+
+	if n.opts.To == "none" {
+		if precision == 0 {
+			val = numfmtRound(val, n.opts.Round)
+		}
+		// Past 2^53 not every number fits in a float, those that don't are an
+		// error rather than a slightly different number
+		if math.Abs(val) >= 1<<53 && !isFloat(match[1], scale, val) {
+			return "", fmt.Errorf("%w: '%s'", ErrValueTooLarge, raw)
+		}
+		return strconv.FormatFloat(val, 'f', precision, 64) + n.opts.Suffix, nil
+	}
+
+	base := 1000.0
+	if n.opts.To != "si" {
+		base = 1024.0
+	}
+
+	power := 0
+	for math.Abs(val) >= base {
+		val = val / base
+		power++
+	}
+
+	// Less than 10 gets one decimal, anything else is a whole number. Rounding
+	// can push the number into the next unit (ie: 999.9K -> 1.0M)
+	if math.Abs(val) < 10 {
+		val = numfmtRound(val*10, n.opts.Round) / 10
+	} else {
+		val = numfmtRound(val, n.opts.Round)
+	}
+
+	if math.Abs(val) >= base {
+		val = val / base
+		power++
+	}
+
+	if power > len(numfmtPrefixes) {
+		return "", fmt.Errorf("%w: '%s'", ErrValueTooLarge, raw)
+	}
+
+	if power == 0 {
+		return strconv.FormatFloat(val, 'f', 0, 64) + n.opts.Suffix, nil
+	}
+
+	decimals := 0
+	if math.Abs(val) < 10 {
+		decimals = 1
+	}
+
+	suffix := numfmtPrefixes[power-1 : power]
+	if n.opts.To == "iec-i" {
+		suffix += "i"
+	}
+
+	return strconv.FormatFloat(val, 'f', decimals, 64) + suffix + n.opts.Suffix, nil
+}
+
+// isFloat tells if the number written as digits, times the scale, is exactly
+// the float
+func isFloat(digits string, scale *big.Int, val float64) bool {
+	exact, ok := new(big.Rat).SetString(digits)
+	if !ok {
+		return false
+	}
+	exact.Mul(exact, new(big.Rat).SetInt(scale))
+
+	f := new(big.Rat).SetFloat64(val)
+	return f != nil && exact.Cmp(f) == 0
+}
+
+// numfmtRound rounds to a whole number using one of numfmt's methods
+func numfmtRound(val float64, method string) float64 {
+	switch method {
+	case "up":
+		return math.Ceil(val)
+	case "down":
+		return math.Floor(val)
+	case "towards-zero":
+		return math.Trunc(val)
+	case "nearest":
+		return math.Round(val)
+	default: // from-zero
+		if val < 0 {
+			return math.Floor(val)
+		}
+		return math.Ceil(val)
+	}
+}
+
+// parseFieldSpec turns a list of fields (ie: 1,3-5,7-) into a function that
+// tells if a field (starting from 1) was selected
+func parseFieldSpec(spec string) (func(int) bool, error) {
+	type span struct{ start, stop int }
+	var spans []span
+
+	r := regexp.MustCompile(`^([0-9]*)(-?)([0-9]*)$`)
+	for _, part := range strings.Split(spec, ",") {
+		match := r.FindStringSubmatch(part)
+		if match == nil || (match[1] == "" && match[3] == "") {
+			return nil, fmt.Errorf("%w: field '%s'", ErrBadNumfmtOption, spec)
+		}
+
+		start, _ := strconv.Atoi(match[1])
+		stop, _ := strconv.Atoi(match[3])
+		switch {
+		case match[2] == "":
+			stop = start
+		case match[1] == "":
+			start = 1
+		case match[3] == "":
+			stop = math.MaxInt32
+		}
+
+		if start < 1 || stop < start {
+			return nil, fmt.Errorf("%w: field '%s'", ErrBadNumfmtOption, spec)
+		}
+		spans = append(spans, span{start, stop})
+	}
+
+	return func(field int) bool {
+		for _, s := range spans {
+			if field >= s.start && field <= s.stop {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// contains determines if the string is in the list
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
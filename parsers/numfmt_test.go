@@ -0,0 +1,157 @@
+package parsers
+
+import (
+	"errors"
+	"testing"
+)
+
+// These cases come from the examples in numfmt's documentation (both the
+// manual and `numfmt --help`), the outputs were checked against GNU coreutils
+// so that scripts can switch over without surprises
+// 	https://www.gnu.org/software/coreutils/manual/html_node/Examples-of-using-numfmt.html
+func TestNumfmtConformance(t *testing.T) {
+	tests := []struct {
+		opts NumfmtOptions
+		in   string
+		out  string
+		err  error
+	}{
+		// Converting a single number from/to human representation
+		{NumfmtOptions{To: "si"}, "500000", "500K", nil},
+		{NumfmtOptions{To: "iec"}, "500000", "489K", nil},
+		{NumfmtOptions{To: "iec-i"}, "500000", "489Ki", nil},
+		{NumfmtOptions{From: "si"}, "1M", "1000000", nil},
+		{NumfmtOptions{From: "iec"}, "1M", "1048576", nil},
+		// with --from=auto, M=Mega, Mi=Mebi
+		{NumfmtOptions{From: "auto"}, "1M", "1000000", nil},
+		{NumfmtOptions{From: "auto"}, "1Mi", "1048576", nil},
+		// Switching from one unit to another
+		{NumfmtOptions{From: "si", To: "iec"}, "1T", "932G", nil},
+		// From `numfmt --help`
+		{NumfmtOptions{To: "si"}, "1000", "1.0K", nil},
+		{NumfmtOptions{To: "iec"}, "2048", "2.0K", nil},
+		{NumfmtOptions{To: "iec-i"}, "4096", "4.0Ki", nil},
+		{NumfmtOptions{From: "si"}, "1K", "1000", nil},
+		{NumfmtOptions{From: "iec"}, "1K", "1024", nil},
+		// Rounding, from-zero by default
+		{NumfmtOptions{To: "si"}, "1001", "1.1K", nil},
+		{NumfmtOptions{To: "si"}, "9999", "10K", nil},
+		{NumfmtOptions{To: "si"}, "10001", "11K", nil},
+		{NumfmtOptions{To: "si"}, "999999", "1.0M", nil},
+		{NumfmtOptions{To: "si"}, "12345.678", "13K", nil},
+		{NumfmtOptions{To: "si", Round: "up"}, "1001", "1.1K", nil},
+		{NumfmtOptions{To: "si", Round: "down"}, "1999", "1.9K", nil},
+		{NumfmtOptions{To: "si", Round: "towards-zero"}, "1999", "1.9K", nil},
+		{NumfmtOptions{To: "si", Round: "nearest"}, "1450", "1.5K", nil},
+		{NumfmtOptions{To: "si", Round: "nearest"}, "1440", "1.4K", nil},
+		{NumfmtOptions{To: "si"}, "-1500", "-1.5K", nil},
+		{NumfmtOptions{To: "si"}, "999", "999", nil},
+		{NumfmtOptions{To: "si"}, "1.5", "2", nil},
+		{NumfmtOptions{To: "iec"}, "1023", "1023", nil},
+		// Precision is kept when not scaling
+		{NumfmtOptions{}, "1.50", "1.50", nil},
+		{NumfmtOptions{From: "si"}, "1.25K", "1250", nil},
+		{NumfmtOptions{From: "si"}, "1.0001K", "1001", nil},
+		// Suffix
+		{NumfmtOptions{To: "si", Suffix: "B"}, "1000", "1.0KB", nil},
+		{NumfmtOptions{From: "si", Suffix: "B"}, "1KB", "1000B", nil},
+		// Padding
+		{NumfmtOptions{Padding: 8}, "1000", "    1000", nil},
+		{NumfmtOptions{Padding: -8}, "1000", "1000    ", nil},
+		{NumfmtOptions{To: "si", Padding: 6}, "1000\n50000", "  1.0K\n   50K", nil},
+		// Fields
+		{NumfmtOptions{To: "si", Field: "2"}, "a  1000  b", "a  1.0K  b", nil},
+		{NumfmtOptions{To: "si", Field: "2"}, "a\t1000", "a 1.0K", nil},
+		{NumfmtOptions{To: "si", Field: "2"}, "a   100000 b", "a     100K b", nil},
+		{NumfmtOptions{To: "si", Field: "2", Padding: 8}, "a   1000 b", "a     1.0K b", nil},
+		{NumfmtOptions{To: "si", Field: "2", Padding: -8}, "a   1000 b", "a 1.0K     b", nil},
+		{NumfmtOptions{To: "si", Field: "1,3"}, "1000 2000 3000 4000", "1.0K 2000 3.0K 4000", nil},
+		{NumfmtOptions{To: "si", Field: "-2"}, "1000 2000 3000 4000", "1.0K 2.0K 3000 4000", nil},
+		{NumfmtOptions{To: "si", Field: "3-"}, "1000 2000 3000 4000", "1000 2000 3.0K 4.0K", nil},
+		{NumfmtOptions{To: "si", Field: "3"}, "1000 2000", "1000 2000", nil},
+		{NumfmtOptions{To: "si"}, "   1000 b", "   1.0K b", nil},
+		// Header
+		{NumfmtOptions{To: "si", Field: "2-", Header: 1}, "h1 h2\na 1000 3000", "h1 h2\na 1.0K 3.0K", nil},
+		// Invalid input
+		{NumfmtOptions{}, "abc", "", ErrInvalidNumber},
+		{NumfmtOptions{}, "1K", "", ErrRejectingSuffix},
+		{NumfmtOptions{From: "iec"}, "1Ki", "", ErrInvalidSuffix},
+		{NumfmtOptions{From: "si"}, "1Ki", "", ErrInvalidSuffix},
+		{NumfmtOptions{From: "iec-i"}, "1K", "", ErrMissingISuffix},
+		{NumfmtOptions{From: "auto"}, "1k", "", ErrInvalidSuffix},
+		{NumfmtOptions{To: "si"}, "1e3", "", ErrInvalidSuffix},
+		{NumfmtOptions{To: "si"}, "1000000000000000000000000000", "", ErrValueTooLarge},
+		{NumfmtOptions{}, "123456789012345678901234567", "", ErrValueTooLarge},
+		{NumfmtOptions{}, "9007199254740993", "", ErrValueTooLarge},
+		{NumfmtOptions{}, "9007199254740992", "9007199254740992", nil},
+		{NumfmtOptions{From: "iec-i"}, "1Ei", "1152921504606846976", nil},
+		{NumfmtOptions{To: "si"}, "1000\nx\n2000", "1.0K", ErrInvalidNumber},
+		{NumfmtOptions{To: "si", Invalid: "fail"}, "1000\nx\n2000", "1.0K\nx\n2.0K", ErrInvalidInput},
+		{NumfmtOptions{To: "si", Invalid: "warn"}, "1000\nx\n2000", "1.0K\nx\n2.0K", nil},
+		{NumfmtOptions{To: "si", Invalid: "ignore"}, "1000\nx\n2000", "1.0K\nx\n2.0K", nil},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			numfmt, err := NewNumfmt(tt.opts)
+			if err != nil {
+				t.Fatalf("Case %d: unexpected error `%v`", i, err)
+			}
+
+			got, err := numfmt.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestNumfmtWarnings(t *testing.T) {
+	tests := []struct {
+		invalid string
+		want    int
+	}{
+		{"warn", 2},
+		{"fail", 2},
+		{"ignore", 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.invalid, func(t *testing.T) {
+			numfmt, _ := NewNumfmt(NumfmtOptions{Invalid: tt.invalid})
+			numfmt.DoFromMachine("1\nx\ny")
+			if got := len(numfmt.Warnings()); got != tt.want {
+				t.Errorf("Case %d: Given = `%s` ; want `%d` ; got `%d`", i, tt.invalid, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewNumfmtOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts NumfmtOptions
+		err  error
+	}{
+		{"defaults", NumfmtOptions{}, nil},
+		{"from", NumfmtOptions{From: "nope"}, ErrBadNumfmtOption},
+		{"to", NumfmtOptions{To: "auto"}, ErrBadNumfmtOption},
+		{"round", NumfmtOptions{Round: "sideways"}, ErrBadNumfmtOption},
+		{"invalid", NumfmtOptions{Invalid: "explode"}, ErrBadNumfmtOption},
+		{"field", NumfmtOptions{Field: "0"}, ErrBadNumfmtOption},
+		{"field range", NumfmtOptions{Field: "3-1"}, ErrBadNumfmtOption},
+		{"field nonsense", NumfmtOptions{Field: "a"}, ErrBadNumfmtOption},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewNumfmt(tt.opts)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Case %d: Given = `%v` ; want `%t` ; got `%t`", i, tt.opts, tt.err, err)
+			}
+		})
+	}
+}
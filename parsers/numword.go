@@ -27,6 +27,9 @@ type NumberWord struct {
 		name   string
 		powers int
 	}
+	// exact keeps every digit after the greatest power instead of rounding to
+	// the first one, ie: 1.234567 million
+	exact bool
 }
 
 // NewNumberWord constructs a NumberWord struct
@@ -62,6 +65,12 @@ func NewNumberWord() *NumberWord {
 	}
 }
 
+// SetExact makes the output read back into the same number, every digit after
+// the greatest power is kept, ie: 1234567 -> 1.234567 million
+func (n *NumberWord) SetExact(exact bool) {
+	n.exact = exact
+}
+
 // CanParseFromMachine ...
 // is it 4 or more characters? (e.g. is it => 1000)
 // is it a (delimited[,. ]) number?
@@ -88,16 +97,27 @@ func (n *NumberWord) CanParseFromMachine(s string) (bool, error) {
 }
 
 // CanParseIntoMachine ...
-// is it a digit word combo? ( <number>[.tenths] <word> )
+// is it a digit word combo? ( <number>[.decimals] <word> )
+// is it not a number grouped with dots? (e.g. 100.000)
 // is the word in the trans table? (case insensitive)
+// are there no more decimals (other than trailing zeros) than the power of the
+// word has zeros?
 func (n *NumberWord) CanParseIntoMachine(s string) (bool, error) {
 	//
-	match, _ := regexp.MatchString(`^[0-9]+([.][0-9])? [a-zA-Z]+$`, s)
+	match, _ := regexp.MatchString(`^[0-9]+([.][0-9]+)? [a-zA-Z]+$`, s)
 	if match {
-		_, word := splitHumanNumberWord(s)
+		num, word := splitHumanNumberWord(s)
+		if regexp.MustCompile(`^[0-9]{1,3}([.]000)+$`).MatchString(num) {
+			return false, ErrNotADigitWordCombo
+		}
+
+		decimals := 0
+		if a := strings.Split(num, "."); len(a) > 1 {
+			decimals = len(strings.TrimRight(a[1], "0"))
+		}
 
 		for _, v := range n.trans {
-			if v.name == word {
+			if v.name == word && decimals <= v.powers {
 				return true, nil
 			}
 		}
@@ -128,27 +148,47 @@ func (n *NumberWord) DoFromMachine(s string) (string, error) {
 	}
 
 	var out strings.Builder
-	out.WriteString(numbers[0])
+	if n.exact {
+		out.WriteString(numbers[0])
+		if digits := strings.TrimRight(strings.Join(numbers[1:], ""), "0"); digits != "" {
+			out.WriteString("." + digits)
+		}
+		out.WriteString(" " + n.trans[len(numbers)].name)
+		return out.String(), nil
+	}
 
+	lead, err := strconv.Atoi(numbers[0])
+	if err != nil {
+		return "", err
+	}
 	num, err := strconv.ParseFloat(numbers[1], 64)
 	if err != nil {
 		return "", err
 	}
 
+	// Rounding up to a whole one is carried over, ie: 1950000 is 2 million
+	// and 999950000 is 1 billion rather than 1000 million
+	power := len(numbers)
 	decimal := int(math.Round(num / 100.0))
+	if decimal == 10 {
+		lead, decimal = lead+1, 0
+	}
+	if _, ok := n.trans[power+1]; ok && lead == 1000 {
+		lead, power = 1, power+1
+	}
 
+	out.WriteString(strconv.Itoa(lead))
 	if decimal > 0 {
 		out.WriteString("." + strconv.Itoa(decimal))
 	}
-	out.WriteString(" " + n.trans[len(numbers)].name)
+	out.WriteString(" " + n.trans[power].name)
 
 	return out.String(), nil
 }
 
 // DoIntoMachine ...
-// Only works with highest power
-// and first digit (e.g. 100.3 Billion, not 100,300 Million)
-// Returns a numeric string e.g. 1 thousand => 1000
+// Only works with highest power (e.g. 100.3 Billion, not 100,300 Million)
+// Returns a numeric string e.g. 1 thousand => 1000, 1.25 million => 1250000
 func (n *NumberWord) DoIntoMachine(s string) (string, error) {
 	num, word := splitHumanNumberWord(s)
 	var power int
@@ -162,8 +202,10 @@ func (n *NumberWord) DoIntoMachine(s string) (string, error) {
 
 	var out strings.Builder
 	if a := strings.Split(num, "."); len(a) > 1 {
-		fmt.Fprintf(&out, "%s%s", a[0], a[1])
-		power--
+		// Trailing zeros don't change the number, ie: 1.50 million
+		decimals := strings.TrimRight(a[1], "0")
+		fmt.Fprintf(&out, "%s%s", a[0], decimals)
+		power -= len(decimals)
 	} else {
 		out.WriteString(num)
 	}
@@ -171,6 +213,12 @@ func (n *NumberWord) DoIntoMachine(s string) (string, error) {
 	return out.String(), nil
 }
 
+// Lossless is only true in exact mode, otherwise only the first digit after
+// the greatest power is kept
+func (n *NumberWord) Lossless() bool {
+	return n.exact
+}
+
 // splitHumanNumberWord takes a digit word pair and returns the individual components
 // <digit>[.<tenths>] <word>
 // Output is lower cased
@@ -183,3 +231,54 @@ func splitHumanNumberWord(s string) (string, string) {
 	word = strings.ToLower(word)
 	return num, word
 }
+
+// spelledNumbers are the words used to write out the numbers below a hundred
+var spelledNumbers = map[string]float64{
+	"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+	"thirteen": 13, "fourteen": 14, "fifteen": 15, "sixteen": 16,
+	"seventeen": 17, "eighteen": 18, "nineteen": 19, "twenty": 20, "thirty": 30,
+	"forty": 40, "fifty": 50, "sixty": 60, "seventy": 70, "eighty": 80,
+	"ninety": 90,
+}
+
+// parseSpelledNumber reads a number written out in words, ie: "two hundred
+// and five", "a dozen" isn't a thing here but "a thousand" is. The words are
+// expected to be lower case and without hyphens
+func parseSpelledNumber(words []string) (float64, error) {
+	scales := map[string]int{}
+	for _, v := range NewNumberWord().trans {
+		scales[v.name] = v.powers
+	}
+
+	total, current, seen := 0.0, 0.0, false
+	for i, w := range words {
+		if v, ok := spelledNumbers[w]; ok {
+			current += v
+		} else if (w == "a" || w == "an") && i == 0 {
+			current = 1
+		} else if w == "hundred" {
+			if current == 0 {
+				current = 1
+			}
+			current *= 100
+		} else if power, ok := scales[w]; ok {
+			if current == 0 {
+				current = 1
+			}
+			total += current * math.Pow10(power)
+			current = 0
+		} else if w == "and" && seen {
+			continue
+		} else {
+			return 0, ErrNotANumber
+		}
+		seen = true
+	}
+
+	if !seen {
+		return 0, ErrNotANumber
+	}
+
+	return total + current, nil
+}
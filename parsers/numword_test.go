@@ -71,6 +71,11 @@ func TestNumberWordCanParseIntoMachine(t *testing.T) {
 		{"10 million", true, nil},
 		{"100 million", true, nil},
 		{"1.3 million", true, nil},
+		// Trailing zeros are fine as long as it doesn't look grouped
+		{"1.0 million", true, nil},
+		{"1.50 million", true, nil},
+		{"1.3000000 million", true, nil},
+		{"1.0000001 million", false, ErrNotADigitWordCombo},
 		// case insensitive
 		{"1 MiLlIon", true, nil},
 	}
@@ -101,6 +106,12 @@ func TestNumberWordDoFromMachine(t *testing.T) {
 		{"100000", "100 thousand", nil},
 		// First decimal
 		{"12345678", "12.3 million", nil},
+		// Rounding up carries over
+		{"1950000", "2 million", nil},
+		{"1999999", "2 million", nil},
+		{"1949999", "1.9 million", nil},
+		{"999950000", "1 billion", nil},
+		{"999949999", "999.9 million", nil},
 		// Delimiters
 		{"1,000,000", "1 million", nil},
 		{"1.000.000", "1 million", nil},
@@ -139,6 +150,8 @@ func TestNumberWordDoIntoMachine(t *testing.T) {
 		{"100 trillion", "100000000000000", nil},
 		{"10.3 million", "10300000", nil},
 		{"100.3 million", "100300000", nil},
+		{"1.0 million", "1000000", nil},
+		{"1.50 million", "1500000", nil},
 		{"1 vigintillion", "1000000000000000000000000000000000000000000000000000000000000000", nil},
 	}
 
@@ -2,7 +2,9 @@ package parsers
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
+	"strings"
 )
 
 // Parser is the contract that the main command line application will use
@@ -11,6 +13,9 @@ type Parser interface {
 	CanParseFromMachine(string) (bool, error)
 	DoIntoMachine(string) (string, error)
 	DoFromMachine(string) (string, error)
+	// Lossless tells if reading the output of DoFromMachine with
+	// DoIntoMachine always gives back the input
+	Lossless() bool
 }
 
 // General purpose errors
@@ -42,6 +47,10 @@ func (e *Empty) DoIntoMachine(string) (string, error) {
 	return "Not Yet Implemented", nil
 }
 
+func (e *Empty) Lossless() bool {
+	return false
+}
+
 func (e *Empty) DoFromMachine(string) (string, error) {
 	return "Not Yet Implemented", nil
 }
@@ -73,3 +82,15 @@ func isDelimitedNumber(s string) bool {
 	match, _ := regexp.MatchString(`^[1-9][0-9]{0,2}([.,_ ][0-9]{3})+$`, s)
 	return match
 }
+
+// alignLines writes name and value pairs one per line with the values lined
+// up, ie:
+// 	network:   10.0.0.0/22
+// 	broadcast: 10.0.3.255
+func alignLines(lines [][2]string) string {
+	var out []string
+	for _, l := range lines {
+		out = append(out, fmt.Sprintf("%-10s %s", l[0]+":", l[1]))
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,298 @@
+package parsers
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrNotAPermission error = errors.New("Not a file permission, ie: 0755, rwxr-xr-x or u+x,go-w")
+
+const (
+	permSetuid = 04000
+	permSetgid = 02000
+	permSticky = 01000
+)
+
+// permClasses are who the permission bits apply to, in the order they're
+// written. The shift moves the `rwx` bits into place and special is the bit
+// that shows up in the class' execute position
+var permClasses = []struct {
+	letter  string
+	shift   uint
+	special uint32
+	marks   string
+}{
+	{"u", 6, permSetuid, "sS"},
+	{"g", 3, permSetgid, "sS"},
+	{"o", 0, permSticky, "tT"},
+}
+
+// Perm converts Unix file permissions between their octal mode and the way
+// `ls -l` writes them, ie:
+// 	0755 <-> rwxr-xr-x
+// 	4755 <-> rwsr-xr-x
+//
+// Going into the machine side of things, symbolic `chmod` expressions (ie:
+// u+x,go-w) are understood too, they're applied to the parser's base mode
+type Perm struct {
+	// words adds an English description of the permissions to the output
+	words bool
+	// base is the mode symbolic expressions are applied to
+	base uint32
+}
+
+// NewPerm constructs a Perm parser
+func NewPerm(words bool, base uint32) *Perm {
+	return &Perm{words: words, base: base & 07777}
+}
+
+// ParsePermBase reads the octal mode used as the base for symbolic
+// expressions, ie: 644 or 0644
+func ParsePermBase(s string) (uint32, error) {
+	if !regexp.MustCompile(`^0?[0-7]{1,4}$`).MatchString(s) {
+		return 0, ErrNotAPermission
+	}
+	n, _ := strconv.ParseUint(s, 8, 32)
+	return uint32(n), nil
+}
+
+// CanParseFromMachine determines if the input is an octal mode, ie: 755, 0755,
+// 4755
+func (p *Perm) CanParseFromMachine(s string) (bool, error) {
+	if !isOctalMode(s) {
+		return false, ErrNotAPermission
+	}
+	return true, nil
+}
+
+// CanParseIntoMachine determines if the input is written the way `ls -l` does
+// or is a symbolic `chmod` expression
+func (p *Perm) CanParseIntoMachine(s string) (bool, error) {
+	if _, err := p.parseHuman(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DoFromMachine writes the mode the way `ls -l` does, followed by what it
+// means in English when the parser was asked for words
+func (p *Perm) DoFromMachine(s string) (string, error) {
+	if ok, err := p.CanParseFromMachine(s); !ok {
+		return "", err
+	}
+
+	mode, _ := ParsePermBase(s)
+	if p.words {
+		return symbolicMode(mode) + "\n" + describeMode(mode), nil
+	}
+	return symbolicMode(mode), nil
+}
+
+// DoIntoMachine gives back the octal mode
+func (p *Perm) DoIntoMachine(s string) (string, error) {
+	mode, err := p.parseHuman(s)
+	if err != nil {
+		return "", err
+	}
+	return formatOctalMode(mode), nil
+}
+
+// Lossless is false, modes are described in more than one notation
+func (p *Perm) Lossless() bool {
+	return false
+}
+
+// parseHuman reads either the `ls -l` notation or a `chmod` expression
+func (p *Perm) parseHuman(s string) (uint32, error) {
+	s = strings.TrimSpace(s)
+	if mode, err := parseSymbolicMode(s); err == nil {
+		return mode, nil
+	}
+	return applyChmod(p.base, s)
+}
+
+// isOctalMode determines if the input looks like a mode, ie: 3 or 4 octal
+// digits with an optional leading 0
+func isOctalMode(s string) bool {
+	return regexp.MustCompile(`^0?[0-7]{3,4}$`).MatchString(s)
+}
+
+// formatOctalMode writes the mode with 4 digits, ie: 0755
+func formatOctalMode(mode uint32) string {
+	out := strconv.FormatUint(uint64(mode), 8)
+	return strings.Repeat("0", 4-len(out)) + out
+}
+
+// symbolicMode writes the mode the way `ls -l` does minus the file type, ie:
+// rwxr-xr-x
+func symbolicMode(mode uint32) string {
+	var out strings.Builder
+	for _, c := range permClasses {
+		bits := (mode >> c.shift) & 7
+		for i, letter := range "rwx" {
+			set := bits&(4>>uint(i)) != 0
+			switch {
+			case letter == 'x' && mode&c.special != 0 && set:
+				out.WriteByte(c.marks[0])
+			case letter == 'x' && mode&c.special != 0:
+				out.WriteByte(c.marks[1])
+			case set:
+				out.WriteRune(letter)
+			default:
+				out.WriteRune('-')
+			}
+		}
+	}
+	return out.String()
+}
+
+// parseSymbolicMode reads the `ls -l` notation, with or without the file type
+// in front, ie: rwxr-xr-x, drwxr-sr-x
+func parseSymbolicMode(s string) (uint32, error) {
+	if !regexp.MustCompile(`^[-dlcbps]?([r-][w-][xsS-]){2}[r-][w-][xtT-]$`).MatchString(s) {
+		return 0, ErrNotAPermission
+	}
+	s = s[len(s)-9:]
+
+	var mode uint32
+	for i, c := range permClasses {
+		triad := s[i*3 : i*3+3]
+		var bits uint32
+		if triad[0] == 'r' {
+			bits |= 4
+		}
+		if triad[1] == 'w' {
+			bits |= 2
+		}
+		switch triad[2] {
+		case 'x':
+			bits |= 1
+		case c.marks[0]:
+			bits |= 1
+			mode |= c.special
+		case c.marks[1]:
+			mode |= c.special
+		}
+		mode |= bits << c.shift
+	}
+
+	return mode, nil
+}
+
+// applyChmod applies a symbolic `chmod` expression to the mode, ie: u+x,go-w.
+// Like `chmod`, a clause without who it applies to applies to everyone
+func applyChmod(mode uint32, expr string) (uint32, error) {
+	clause := regexp.MustCompile(`^([ugoa]*)((?:[-+=][rwxXst]*)+)$`)
+	op := regexp.MustCompile(`([-+=])([rwxXst]*)`)
+
+	for _, part := range strings.Split(expr, ",") {
+		match := clause.FindStringSubmatch(part)
+		if match == nil {
+			return 0, ErrNotAPermission
+		}
+
+		who := match[1]
+		if who == "" || strings.Contains(who, "a") {
+			who = "ugo"
+		}
+
+		for _, o := range op.FindAllStringSubmatch(match[2], -1) {
+			var bits uint32
+			var mask uint32
+			for _, c := range permClasses {
+				if !strings.Contains(who, c.letter) {
+					continue
+				}
+				mask |= 7<<c.shift | c.special
+				for _, letter := range o[2] {
+					switch letter {
+					case 'r':
+						bits |= 4 << c.shift
+					case 'w':
+						bits |= 2 << c.shift
+					case 'x':
+						bits |= 1 << c.shift
+					case 'X':
+						// Only executable when someone could already execute it
+						if mode&0111 != 0 {
+							bits |= 1 << c.shift
+						}
+					case 's':
+						if c.special != permSticky {
+							bits |= c.special
+						}
+					case 't':
+						if c.special == permSticky {
+							bits |= c.special
+						}
+					}
+				}
+			}
+
+			switch o[1] {
+			case "+":
+				mode |= bits
+			case "-":
+				mode &^= bits
+			case "=":
+				mode = mode&^mask | bits
+			}
+		}
+	}
+
+	return mode, nil
+}
+
+// describeMode writes what the mode allows in English, ie: owner can
+// read/write/execute; group and others can read/execute
+func describeMode(mode uint32) string {
+	abilities := make([]string, len(permClasses))
+	for i, c := range permClasses {
+		var can []string
+		for j, name := range []string{"read", "write", "execute"} {
+			if (mode>>c.shift)&(4>>uint(j)) != 0 {
+				can = append(can, name)
+			}
+		}
+		abilities[i] = strings.Join(can, "/")
+	}
+
+	// Classes that can do the same thing are described together
+	var parts []string
+	switch {
+	case abilities[0] == abilities[1] && abilities[1] == abilities[2]:
+		parts = append(parts, describeAbility("everyone", abilities[0], false))
+	case abilities[1] == abilities[2]:
+		parts = append(parts, describeAbility("owner", abilities[0], false), describeAbility("group and others", abilities[1], true))
+	case abilities[0] == abilities[1]:
+		parts = append(parts, describeAbility("owner and group", abilities[0], true), describeAbility("others", abilities[2], true))
+	default:
+		parts = append(parts, describeAbility("owner", abilities[0], false), describeAbility("group", abilities[1], false), describeAbility("others", abilities[2], true))
+	}
+
+	if mode&permSetuid != 0 {
+		parts = append(parts, "runs as its owner (setuid)")
+	}
+	if mode&permSetgid != 0 {
+		parts = append(parts, "runs as its group, new files in a directory get its group (setgid)")
+	}
+	if mode&permSticky != 0 {
+		parts = append(parts, "only owners can delete or rename entries (sticky)")
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// describeAbility writes what a class can do, `plural` picks the verb for
+// when nothing is allowed
+func describeAbility(who, ability string, plural bool) string {
+	if ability != "" {
+		return who + " can " + ability
+	}
+	if plural {
+		return who + " have no access"
+	}
+	return who + " has no access"
+}
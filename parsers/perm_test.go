@@ -0,0 +1,95 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestPermDoFromMachine(t *testing.T) {
+	tests := []struct {
+		words bool
+		in    string
+		out   string
+		err   error
+	}{
+		{false, "0755", "rwxr-xr-x", nil},
+		{false, "755", "rwxr-xr-x", nil},
+		{false, "0644", "rw-r--r--", nil},
+		{false, "4755", "rwsr-xr-x", nil},
+		{false, "2755", "rwxr-sr-x", nil},
+		{false, "1777", "rwxrwxrwt", nil},
+		{false, "6644", "rwSr-Sr--", nil},
+		{false, "1666", "rw-rw-rwT", nil},
+		{false, "0000", "---------", nil},
+		{false, "0855", "", ErrNotAPermission},
+		{false, "75", "", ErrNotAPermission},
+		{false, "07777", "rwsrwsrwt", nil},
+		{false, "17777", "", ErrNotAPermission},
+		{true, "0755", "rwxr-xr-x\nowner can read/write/execute; group and others can read/execute", nil},
+		{true, "0777", "rwxrwxrwx\neveryone can read/write/execute", nil},
+		{true, "0700", "rwx------\nowner can read/write/execute; group and others have no access", nil},
+		{true, "0640", "rw-r-----\nowner can read/write; group can read; others have no access", nil},
+		{true, "0660", "rw-rw----\nowner and group can read/write; others have no access", nil},
+		{true, "0000", "---------\neveryone has no access", nil},
+		{true, "4755", "rwsr-xr-x\nowner can read/write/execute; group and others can read/execute; runs as its owner (setuid)", nil},
+		{true, "1777", "rwxrwxrwt\neveryone can read/write/execute; only owners can delete or rename entries (sticky)", nil},
+	}
+
+	for i, tt := range tests {
+		perm := NewPerm(tt.words, 0)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := perm.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestPermDoIntoMachine(t *testing.T) {
+	tests := []struct {
+		base uint32
+		in   string
+		out  string
+		err  error
+	}{
+		{0, "rwxr-xr-x", "0755", nil},
+		{0, "drwxr-sr-x", "2755", nil},
+		{0, "-rwsr-xr-x", "4755", nil},
+		{0, "drwxrwxrwt", "1777", nil},
+		{0, "rwSr-Sr-T", "7644", nil},
+		{0, "rwxr-xr-t", "1755", nil},
+		{0, "lrwxrwxrwx", "0777", nil},
+		// chmod expressions
+		{0644, "u+x,go-w", "0744", nil},
+		{0666, "u+x,go-w", "0744", nil},
+		{0, "a=r", "0444", nil},
+		{0, "=rw", "0666", nil},
+		{0755, "o=", "0750", nil},
+		{0644, "+x", "0755", nil},
+		{0644, "a+X", "0644", nil},
+		{0744, "a+X", "0755", nil},
+		{0755, "u+s,g+s", "6755", nil},
+		{0777, "+t", "1777", nil},
+		{06755, "ug-s", "0755", nil},
+		{0755, "g=rw-w+x", "0755", nil},
+		{0, "u+y", "", ErrNotAPermission},
+		{0, "rwxr-xr-", "", ErrNotAPermission},
+		{0, "0755", "", ErrNotAPermission},
+	}
+
+	for i, tt := range tests {
+		perm := NewPerm(false, tt.base)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := perm.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrIncompatibleUnits error = errors.New("Can't convert between an amount of data and a rate")
+var ErrNotARate error = errors.New("Not a data rate, ie: 100Mbps or 12.5 MB/s")
+var ErrBadSize error = errors.New("Not an amount of data, ie: 10GB or 1.5 GiB")
+
+// rateUnits are the units used when a target unit isn't given, they're picked
+// based on the magnitude of the number
+var rateUnits = map[string][]string{
+	"si":  {"B/s", "KB/s", "MB/s", "GB/s", "TB/s", "PB/s", "EB/s", "ZB/s", "YB/s"},
+	"iec": {"B/s", "KiB/s", "MiB/s", "GiB/s", "TiB/s", "PiB/s", "EiB/s", "ZiB/s", "YiB/s"},
+}
+
+// Rate converts data rates (and amounts of data) between bits, bytes and their
+// multiples. The machine side of things is always bytes per second.
+//
+// Unlike `Size`, the unit symbols here are self describing:
+// 	K, M, G...     powers of 1000
+// 	Ki, Mi, Gi...  powers of 1024
+// 	B, byte(s)     bytes
+// 	b, bit(s)      bits
+// 	/s, ps         per second
+//
+// So `Gbps`, `Gbit/s`, `MB/s` and `MiB/s` are all understood. Note that here a
+// lowercase `b` is always bits since that's how network speeds are written
+type Rate struct {
+	// units is the standard used when picking a unit for the output, SI or IEC
+	units string
+	// to is the unit to convert into, an empty string means pick one based on
+	// the magnitude
+	to string
+	// exact shows as many decimals as it takes for the rate to read back into
+	// the same amount of bytes
+	exact bool
+}
+
+// NewRate constructs a Rate parser, units defaults to "si" if anything
+// unknown is passed
+func NewRate(units, to string) *Rate {
+	if _, ok := rateUnits[units]; !ok {
+		units = "si"
+	}
+	return &Rate{units: units, to: to}
+}
+
+// SetExact makes the output read back into the same amount of bytes, the
+// rates aren't rounded to 2 decimals
+func (r *Rate) SetExact(exact bool) {
+	r.exact = exact
+}
+
+// CanParseFromMachine determines if the input is a number of bytes per second
+// or a rate/amount that can be converted into the target unit
+func (r *Rate) CanParseFromMachine(s string) (bool, error) {
+	_, perSecond, err := r.parseFromInput(s)
+	if err != nil {
+		return false, err
+	}
+
+	if r.to != "" {
+		_, toPerSecond, err := parseDataUnit(r.to)
+		if err != nil {
+			return false, err
+		}
+		if toPerSecond != perSecond {
+			return false, ErrIncompatibleUnits
+		}
+	}
+
+	return true, nil
+}
+
+// CanParseIntoMachine determines if the input is a number followed by a rate
+// unit, ie: 100Mbps, 12.5 MB/s
+func (r *Rate) CanParseIntoMachine(s string) (bool, error) {
+	_, perSecond, err := parseDataQuantity(s)
+	if err != nil {
+		return false, err
+	}
+
+	if !perSecond {
+		return false, ErrNotARate
+	}
+
+	return true, nil
+}
+
+// DoFromMachine writes the rate in the target unit, or in the biggest unit
+// that keeps the number at or above 1 when there's no target
+func (r *Rate) DoFromMachine(s string) (string, error) {
+	if ok, err := r.CanParseFromMachine(s); !ok {
+		return "", err
+	}
+
+	bytes, perSecond, _ := r.parseFromInput(s)
+
+	if r.to != "" {
+		factor, _, _ := parseDataUnit(r.to)
+		return r.formatNumber(bytes/factor) + " " + r.to, nil
+	}
+
+	base := 1000.0
+	if r.units == "iec" {
+		base = 1024.0
+	}
+
+	units := rateUnits[r.units]
+	idx := 0
+	for idx+1 < len(units) && bytes >= math.Pow(base, float64(idx+1)) {
+		idx++
+	}
+
+	unit := units[idx]
+	if !perSecond {
+		unit = strings.TrimSuffix(unit, "/s")
+	}
+
+	return r.formatNumber(bytes/math.Pow(base, float64(idx))) + " " + unit, nil
+}
+
+// formatNumber rounds to 2 decimals unless the rate has to be exact
+func (r *Rate) formatNumber(n float64) string {
+	if r.exact {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return formatRateNumber(n)
+}
+
+// DoIntoMachine gives back the rate in bytes per second, rounded to the
+// nearest byte
+func (r *Rate) DoIntoMachine(s string) (string, error) {
+	if ok, err := r.CanParseIntoMachine(s); !ok {
+		return "", err
+	}
+
+	bytes, _, _ := parseDataQuantity(s)
+	return strconv.FormatFloat(math.Round(bytes), 'f', 0, 64), nil
+}
+
+// Lossless is only true in exact mode, otherwise rates are rounded to 2
+// decimals
+func (r *Rate) Lossless() bool {
+	return r.exact
+}
+
+// TransferTime figures out how long it takes to move `size` (ie: 10GB) at the
+// given `rate` (ie: 100Mbps)
+func (r *Rate) TransferTime(size, rate string) (string, error) {
+	bytes, perSecond, err := parseDataQuantity(size)
+	if errors.Is(err, ErrUnparsable) {
+		return "", fmt.Errorf("%w: '%s'", ErrBadSize, size)
+	}
+	if err != nil {
+		return "", err
+	}
+	if perSecond {
+		return "", ErrIncompatibleUnits
+	}
+
+	speed, perSecond, err := r.parseFromInput(rate)
+	if err != nil {
+		return "", err
+	}
+	if !perSecond {
+		return "", ErrNotARate
+	}
+	if speed == 0 {
+		return "", ErrTooSmall
+	}
+
+	// Transfers that take longer than a time.Duration holds (about 292 years)
+	// aren't worth writing out
+	seconds := math.Ceil(bytes / speed)
+	if seconds > float64(math.MaxInt64/int64(time.Second)) {
+		return "", ErrTooLarge
+	}
+	return humanizeDurationShort(time.Duration(seconds) * time.Second), nil
+}
+
+// parseFromInput reads a machine number (bytes per second) or a quantity with
+// its unit
+func (r *Rate) parseFromInput(s string) (float64, bool, error) {
+	if isMachineNumber(s) {
+		n, err := strconv.ParseFloat(s, 64)
+		return n, true, err
+	}
+	return parseDataQuantity(s)
+}
+
+// parseDataQuantity splits the input into the number and the unit and gives
+// back the amount of bytes (per second if it's a rate)
+func parseDataQuantity(s string) (float64, bool, error) {
+	r := regexp.MustCompile(`^([0-9]{1,3}(,[0-9]{3})+|[0-9]+)(\.[0-9]+)?\s*([a-zA-Z/ ]+)$`)
+	match := r.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return 0, false, ErrUnparsable
+	}
+
+	n, err := strconv.ParseFloat(strings.Replace(match[1], ",", "", -1)+match[3], 64)
+	if err != nil {
+		return 0, false, ErrNotANumber
+	}
+
+	factor, perSecond, err := parseDataUnit(match[4])
+	if err != nil {
+		return 0, false, err
+	}
+
+	return n * factor, perSecond, nil
+}
+
+// parseDataUnit gives back how many bytes one of the unit is and if the unit
+// is a rate. Both symbols (ie: Mbps, MiB/s) and names (ie: megabits per
+// second) are understood
+func parseDataUnit(unit string) (float64, bool, error) {
+	unit = strings.TrimSpace(unit)
+	perSecond := regexp.MustCompile(`(/s|/sec|ps| per second)$`)
+	rate := perSecond.MatchString(unit)
+	unit = perSecond.ReplaceAllString(unit, "")
+
+	base, power, bits := 1000.0, 0, false
+
+	symbol := regexp.MustCompile(`^([kKmMgGtTpPeEzZyY])?(i)?(B|b|bits?|bytes?)$`)
+	name := regexp.MustCompile(`(?i)^([a-z]*?)(bytes?|bits?)$`)
+
+	if match := symbol.FindStringSubmatch(unit); match != nil {
+		if match[1] != "" {
+			power = strings.Index("kmgtpezy", strings.ToLower(match[1])) + 1
+		}
+		if match[2] != "" {
+			base = 1024.0
+		}
+		bits = match[3] == "b" || strings.HasPrefix(match[3], "bit")
+	} else if match := name.FindStringSubmatch(unit); match != nil {
+		prefix := strings.ToLower(match[1])
+		found := prefix == ""
+		for units, names := range sizeUnitNames {
+			for i, n := range names {
+				if n == prefix {
+					found, power = true, i+1
+					if units == "iec" {
+						base = 1024.0
+					}
+				}
+			}
+		}
+		if !found {
+			return 0, false, ErrUnknownSuffix
+		}
+		bits = strings.HasPrefix(strings.ToLower(match[2]), "bit")
+	} else {
+		return 0, false, ErrUnknownSuffix
+	}
+
+	factor := math.Pow(base, float64(power))
+	if bits {
+		factor = factor / 8
+	}
+
+	return factor, rate, nil
+}
+
+// formatRateNumber shows up to 2 decimals dropping any trailing zeros
+func formatRateNumber(n float64) string {
+	out := strconv.FormatFloat(n, 'f', 2, 64)
+	return strings.TrimRight(strings.TrimRight(out, "0"), ".")
+}
@@ -0,0 +1,170 @@
+package parsers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRateCanParseFromMachine(t *testing.T) {
+	tests := []struct {
+		to  string
+		in  string
+		out bool
+		err error
+	}{
+		{"", "abc", false, ErrUnparsable},
+		{"", "1000", true, nil},
+		{"", "1Gbps", true, nil},
+		{"", "1 GB", true, nil},
+		{"", "1Gxx", false, ErrUnknownSuffix},
+		{"MB/s", "1Gbps", true, nil},
+		{"MB/s", "1000", true, nil},
+		{"MB", "1Gbps", false, ErrIncompatibleUnits},
+		{"MB/s", "1GB", false, ErrIncompatibleUnits},
+		{"furlongs", "1GB", false, ErrUnknownSuffix},
+	}
+
+	for i, tt := range tests {
+		rate := NewRate("si", tt.to)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := rate.CanParseFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestRateCanParseIntoMachine(t *testing.T) {
+	tests := []struct {
+		in  string
+		out bool
+		err error
+	}{
+		{"1000", false, ErrUnparsable},
+		{"1 GB", false, ErrNotARate},
+		{"1Gbps", true, nil},
+		{"1 Gbps", true, nil},
+		{"1GBps", true, nil},
+		{"1Gbit/s", true, nil},
+		{"12.5 MB/s", true, nil},
+		{"1,000 KiB/s", true, nil},
+		{"100 megabits per second", true, nil},
+		{"1Gxps", false, ErrUnknownSuffix},
+	}
+
+	rate := NewRate("si", "")
+	for i, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := rate.CanParseIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestRateDoFromMachine(t *testing.T) {
+	tests := []struct {
+		units string
+		to    string
+		in    string
+		out   string
+		err   error
+	}{
+		{"si", "", "100", "100 B/s", nil},
+		{"si", "", "1000", "1 KB/s", nil},
+		{"si", "", "125000000", "125 MB/s", nil},
+		{"si", "", "1234567", "1.23 MB/s", nil},
+		{"iec", "", "1048576", "1 MiB/s", nil},
+		{"si", "", "1Gbps", "125 MB/s", nil},
+		{"si", "", "8 Gb", "1 GB", nil},
+		{"si", "MB/s", "1Gbps", "125 MB/s", nil},
+		{"si", "Mbps", "125 MB/s", "1000 Mbps", nil},
+		{"si", "Gbit/s", "125000000", "1 Gbit/s", nil},
+		{"si", "MiB/s", "1Gbps", "119.21 MiB/s", nil},
+		{"si", "bits", "1KB", "8000 bits", nil},
+		{"si", "MB", "1Gbps", "", ErrIncompatibleUnits},
+	}
+
+	for i, tt := range tests {
+		rate := NewRate(tt.units, tt.to)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := rate.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestRateDoIntoMachine(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+		err error
+	}{
+		{"1Gbps", "125000000", nil},
+		{"1GBps", "1000000000", nil},
+		{"8 bps", "1", nil},
+		{"1 MiB/s", "1048576", nil},
+		{"1 Mibit/s", "131072", nil},
+		{"12.5 MB/s", "12500000", nil},
+		{"1 GB", "", ErrNotARate},
+	}
+
+	rate := NewRate("si", "")
+	for i, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := rate.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestRateTransferTime(t *testing.T) {
+	tests := []struct {
+		size string
+		rate string
+		out  string
+		err  error
+	}{
+		{"10GB", "100Mbps", "13m 20s", nil},
+		{"1 GiB", "1 MiB/s", "17m 4s", nil},
+		{"1TB", "1 MB/s", "11d 13h 46m 40s", nil},
+		{"1 KB", "1 GB/s", "1s", nil},
+		{"10GB", "1000", "115d 17h 46m 40s", nil},
+		{"10GB/s", "100Mbps", "", ErrIncompatibleUnits},
+		{"10GB", "100Mb", "", ErrNotARate},
+		{"10GB", "0", "", ErrTooSmall},
+		{"lots", "100Mbps", "", ErrBadSize},
+		{"1EB", "1", "", ErrTooLarge},
+	}
+
+	rate := NewRate("si", "")
+	for i, tt := range tests {
+		t.Run(tt.size+" at "+tt.rate, func(t *testing.T) {
+			got, err := rate.TransferTime(tt.size, tt.rate)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s at %s` ; want `%s` ; got `%s`", i, tt.size, tt.rate, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Case %d: Given = `%s at %s` ; want `%t` ; got `%t`", i, tt.size, tt.rate, tt.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNotARatio error = errors.New("Not a ratio, ie: 0.75, 75%, 3 out of 4 or 3:4")
+var ErrUnknownRatioNotation error = errors.New("Unknown ratio notation")
+var ErrUnknownPeriod error = errors.New("Unknown period, use day, week, month or year")
+
+// ratioNotations are the ways a ratio can be written, `nines` is the downtime
+// an availability allows for
+var ratioNotations = []string{"percent", "odds", "fraction", "bps", "nines"}
+
+// ratioPeriods are the periods downtime is measured over, in seconds. A month
+// is a twelfth of a (non leap) year, which is how most SLA calculators do it
+var ratioPeriods = map[string]float64{
+	"day":   86400,
+	"week":  7 * 86400,
+	"month": 365 * 86400 / 12,
+	"year":  365 * 86400,
+}
+
+// Ratio humanizes proportions, the machine side of things is a decimal (ie:
+// 0.75) and the human side is any of:
+// 	75%              percent
+// 	3 in 4           odds, `about 1 in 3` when it isn't exact
+// 	3/4              fraction
+// 	7500 bps         basis points (hundredths of a percent)
+// 	3 out of 4, 3:4  only read, never written
+//
+// Fractions are only as precise as the input, ie: 0.3333 is 1/3 since that's
+// as close as 4 decimals get to a third
+type Ratio struct {
+	// to is the notation the ratio is written in, an empty string means all of
+	// them
+	to string
+	// per is the period downtime is shown for in the nines notation
+	per string
+}
+
+// NewRatio constructs a Ratio parser, per defaults to a month
+func NewRatio(to, per string) (*Ratio, error) {
+	if to != "" && !contains(ratioNotations, to) {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownRatioNotation, to)
+	}
+	if per == "" {
+		per = "month"
+	}
+	if _, ok := ratioPeriods[per]; !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownPeriod, per)
+	}
+	return &Ratio{to: to, per: per}, nil
+}
+
+// CanParseFromMachine determines if the input is a ratio, the decimal is what
+// we expect but any of the other notations can be rewritten too
+func (r *Ratio) CanParseFromMachine(s string) (bool, error) {
+	if _, _, err := r.parse(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CanParseIntoMachine determines if the input is a ratio in one of the human
+// notations
+func (r *Ratio) CanParseIntoMachine(s string) (bool, error) {
+	if _, _, err := parseRatio(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DoFromMachine writes the ratio in every notation (or the one asked for)
+func (r *Ratio) DoFromMachine(s string) (string, error) {
+	value, tolerance, err := r.parse(s)
+	if err != nil {
+		return "", err
+	}
+
+	switch r.to {
+	case "percent":
+		return formatPercent(value), nil
+	case "odds":
+		if value > 1 {
+			return "", ErrNotARatio
+		}
+		return formatOdds(value), nil
+	case "fraction":
+		return formatFraction(value, tolerance), nil
+	case "bps":
+		return formatUnitValue(value*10000) + " bps", nil
+	case "nines":
+		return r.downtime(value)
+	}
+
+	lines := [][2]string{{"percent", formatPercent(value)}}
+	if value <= 1 {
+		lines = append(lines, [2]string{"odds", formatOdds(value)})
+	}
+	lines = append(lines,
+		[2]string{"fraction", formatFraction(value, tolerance)},
+		[2]string{"bps", formatUnitValue(value*10000) + " bps"},
+	)
+	return alignLines(lines), nil
+}
+
+// DoIntoMachine gives back the decimal for a ratio written by a human
+func (r *Ratio) DoIntoMachine(s string) (string, error) {
+	value, _, err := parseRatio(s)
+	if err != nil {
+		return "", err
+	}
+	return formatUnitValue(value), nil
+}
+
+// Lossless is false, ratios are rounded to what's worth reading
+func (r *Ratio) Lossless() bool {
+	return false
+}
+
+// parse reads the input for the from direction, in the nines notation a bare
+// number above 1 is a percentage since nobody writes availability as 0.9995
+func (r *Ratio) parse(s string) (float64, float64, error) {
+	value, tolerance, err := parseRatio(s)
+	if err == nil && r.to == "nines" && value > 1 && value <= 100 && isDecimalNumber(strings.TrimSpace(s)) {
+		value, tolerance = value/100, tolerance/100
+	}
+	return value, tolerance, err
+}
+
+// downtime writes how long something can be down for in the period and still
+// be available for the given ratio of the time
+func (r *Ratio) downtime(value float64) (string, error) {
+	if value > 1 {
+		return "", ErrNotARatio
+	}
+	if value == 1 {
+		return "no downtime", nil
+	}
+
+	seconds := (1 - value) * ratioPeriods[r.per]
+	out := humanizeDurationShort(time.Duration(math.Round(seconds)) * time.Second)
+	if seconds < 1 {
+		out = formatUnitValue(math.Round(seconds*1000)) + "ms"
+	}
+	return out + " of downtime per " + r.per, nil
+}
+
+// parseRatio reads any of the notations and gives back the ratio along with
+// how precise it is (ie: 0.333 is anything between 0.3325 and 0.3335)
+func parseRatio(s string) (float64, float64, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = regexp.MustCompile(`^(?:about|around|approximately|roughly|~)\s*`).ReplaceAllString(s, "")
+
+	if m := regexp.MustCompile(`^(.+?)\s*(%|percent|bps|bp|basis points?)$`).FindStringSubmatch(s); m != nil {
+		value, decimals, err := parseRatioNumber(m[1])
+		if err != nil {
+			return 0, 0, err
+		}
+		scale := 2
+		if strings.HasPrefix(m[2], "b") {
+			scale = 4
+		}
+		return value / math.Pow10(scale), ratioTolerance(decimals + scale), nil
+	}
+
+	if m := regexp.MustCompile(`^(.+?)\s*(?:\s(?:out of|in)\s|[:/])\s*(.+)$`).FindStringSubmatch(s); m != nil {
+		a, _, err := parseRatioNumber(m[1])
+		if err != nil {
+			return 0, 0, err
+		}
+		b, _, err := parseRatioNumber(m[2])
+		if err != nil || b == 0 {
+			return 0, 0, ErrNotARatio
+		}
+		return a / b, 0, nil
+	}
+
+	value, decimals, err := parseRatioNumber(s)
+	return value, ratioTolerance(decimals), err
+}
+
+// parseRatioNumber reads a positive number, in digits or spelled out, along
+// with how many decimals it was written with
+func parseRatioNumber(s string) (float64, int, error) {
+	s = strings.TrimSpace(s)
+	if isDecimalNumber(s) {
+		value, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, 0, ErrNotARatio
+		}
+		decimals := 0
+		if i := strings.Index(s, "."); i >= 0 {
+			decimals = len(s) - i - 1
+		}
+		return value, decimals, nil
+	}
+
+	value, err := parseSpelledNumber(strings.Fields(strings.ReplaceAll(s, "-", " ")))
+	if err != nil {
+		return 0, 0, ErrNotARatio
+	}
+	return value, 0, nil
+}
+
+// ratioTolerance is half of the last decimal place, ratios with fewer than 3
+// decimals are taken as exact though, otherwise 0.7 would be 2/3
+func ratioTolerance(decimals int) float64 {
+	if decimals < 3 {
+		return 0
+	}
+	return 0.5 * math.Pow10(-decimals)
+}
+
+// formatPercent shows enough decimals for the distance to 0% or 100% to have
+// two significant digits, that way 99.95% isn't rounded into 100%
+func formatPercent(value float64) string {
+	percent := value * 100
+	distance := math.Min(math.Abs(percent), math.Abs(100-percent))
+
+	decimals := 1
+	if distance > 0 {
+		decimals = int(math.Max(1, -math.Floor(math.Log10(distance))+1))
+	}
+
+	out := strconv.FormatFloat(percent, 'f', decimals, 64)
+	return strings.TrimRight(strings.TrimRight(out, "0"), ".") + "%"
+}
+
+// formatOdds writes the ratio as `1 in N`, or as `N in 10` or less for the
+// ones too big to read that way, ie: 0.3333 -> about 1 in 3, 0.75 -> 3 in 4.
+// Ratios close to 1 are written as the odds of not happening
+func formatOdds(value float64) string {
+	switch {
+	case value == 0:
+		return "none"
+	case value == 1:
+		return "all"
+	case value > 0.9:
+		return "all but " + formatOdds(1-value)
+	}
+
+	num, den := 1.0, math.Round(1/value)
+	if value > 0.5 {
+		// The closest fraction out of ten, the smallest one wins a tie
+		for d := 2.0; d <= 10; d++ {
+			n := math.Round(value * d)
+			if math.Abs(n/d-value) < math.Abs(num/den-value)-1e-12 {
+				num, den = n, d
+			}
+		}
+	}
+
+	out := formatUnitValue(num) + " in " + formatUnitValue(den)
+	if math.Abs(num/den-value) > 1e-9 {
+		out = "about " + out
+	}
+	return out
+}
+
+// formatFraction writes the fraction with the smallest denominator that's
+// within the tolerance of the value, found through its continued fraction
+func formatFraction(value, tolerance float64) string {
+	tolerance = math.Max(tolerance, 1e-9)
+
+	// h and k are the numerators and denominators of the last two convergents
+	h0, h1 := 0.0, 1.0
+	k0, k1 := 1.0, 0.0
+	x := value
+	for i := 0; i < 64; i++ {
+		a := math.Floor(x)
+		h0, h1 = h1, a*h1+h0
+		k0, k1 = k1, a*k1+k0
+		if math.Abs(h1/k1-value) <= tolerance || x == a || k1 > 1e9 {
+			break
+		}
+		x = 1 / (x - a)
+	}
+
+	if k1 == 1 {
+		return formatUnitValue(h1)
+	}
+	return formatUnitValue(h1) + "/" + formatUnitValue(k1)
+}
+
+// isDecimalNumber validates that the input is a positive number written with
+// digits, with or without decimals
+func isDecimalNumber(s string) bool {
+	match, _ := regexp.MatchString(`^(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)$`, s)
+	return match
+}
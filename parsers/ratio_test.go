@@ -0,0 +1,101 @@
+package parsers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRatioDoFromMachine(t *testing.T) {
+	tests := []struct {
+		to  string
+		per string
+		in  string
+		out string
+		err error
+	}{
+		{"", "", "0.3333", "percent:   33.3%\nodds:      about 1 in 3\nfraction:  1/3\nbps:       3333 bps", nil},
+		{"", "", "0.75", "percent:   75%\nodds:      3 in 4\nfraction:  3/4\nbps:       7500 bps", nil},
+		{"", "", "1.5", "percent:   150%\nfraction:  3/2\nbps:       15000 bps", nil},
+		{"percent", "", "0.9995", "99.95%", nil},
+		{"percent", "", "0.00001", "0.001%", nil},
+		{"odds", "", "0.7", "7 in 10", nil},
+		{"odds", "", "0.001", "1 in 1000", nil},
+		{"odds", "", "0.9995", "all but 1 in 2000", nil},
+		{"odds", "", "0", "none", nil},
+		{"odds", "", "1.5", "", ErrNotARatio},
+		// 0.7 is exact, a third is only guessed with 3 decimals or more
+		{"fraction", "", "0.7", "7/10", nil},
+		{"fraction", "", "0.667", "2/3", nil},
+		{"fraction", "", "0.125", "1/8", nil},
+		{"bps", "", "0.0125", "125 bps", nil},
+		// Nines
+		{"nines", "", "99.95%", "21m 54s of downtime per month", nil},
+		{"nines", "", "99.95", "21m 54s of downtime per month", nil},
+		{"nines", "", "0.9995", "21m 54s of downtime per month", nil},
+		{"nines", "year", "99.9", "8h 45m 36s of downtime per year", nil},
+		{"nines", "day", "99.999", "864ms of downtime per day", nil},
+		{"nines", "", "100%", "no downtime", nil},
+		{"nines", "", "150%", "", ErrNotARatio},
+		// Not ratios
+		{"", "", "abc", "", ErrNotARatio},
+		{"", "", "-0.5", "", ErrNotARatio},
+		{"", "", "3 out of 0", "", ErrNotARatio},
+	}
+
+	for i, tt := range tests {
+		ratio, _ := NewRatio(tt.to, tt.per)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ratio.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestRatioDoIntoMachine(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+		err error
+	}{
+		{"3 out of 4", "0.75", nil},
+		{"75%", "0.75", nil},
+		{"75 percent", "0.75", nil},
+		{"3:4", "0.75", nil},
+		{"3/4", "0.75", nil},
+		{"about 1 in 3", "0.333333", nil},
+		{"three out of four", "0.75", nil},
+		{"250 bps", "0.025", nil},
+		{"12.5 basis points", "0.00125", nil},
+		{"99.95%", "0.9995", nil},
+		{"one in a thousand", "0.001", nil},
+		{"lots", "", ErrNotARatio},
+		{"1:0", "", ErrNotARatio},
+	}
+
+	ratio, _ := NewRatio("", "")
+	for i, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ratio.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestNewRatio(t *testing.T) {
+	if _, err := NewRatio("decimal", ""); !errors.Is(err, ErrUnknownRatioNotation) {
+		t.Errorf("want `%v` ; got `%v`", ErrUnknownRatioNotation, err)
+	}
+	if _, err := NewRatio("nines", "fortnight"); !errors.Is(err, ErrUnknownPeriod) {
+		t.Errorf("want `%v` ; got `%v`", ErrUnknownPeriod, err)
+	}
+}
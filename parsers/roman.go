@@ -0,0 +1,208 @@
+package parsers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrNotARomanNumeral error = errors.New("Not a Roman numeral")
+var ErrNotCanonical error = errors.New("Roman numeral is not written in its canonical form")
+
+// vinculum is the combining overline used to multiply a numeral by 1000
+const vinculum = '̅'
+
+// romanSymbols are ordered from largest to smallest so that they can be
+// consumed greedily when converting from a number
+var romanSymbols = []struct {
+	symbol string
+	value  int
+}{
+	{"M", 1000},
+	{"CM", 900},
+	{"D", 500},
+	{"CD", 400},
+	{"C", 100},
+	{"XC", 90},
+	{"L", 50},
+	{"XL", 40},
+	{"X", 10},
+	{"IX", 9},
+	{"V", 5},
+	{"IV", 4},
+	{"I", 1},
+}
+
+var romanValues = map[rune]int{
+	'I': 1,
+	'V': 5,
+	'X': 10,
+	'L': 50,
+	'C': 100,
+	'D': 500,
+	'M': 1000,
+}
+
+// Roman converts numbers to and from Roman numerals
+type Roman struct {
+	// lenient allows non canonical numerals like `IIII` or `VX`
+	lenient bool
+	// useVinculum allows numbers bigger than 3999 by using an overline on top
+	// of the numerals to multiply them by 1000, ie: V̅ is 5000
+	useVinculum bool
+}
+
+// NewRoman constructs a Roman parser
+func NewRoman(lenient, useVinculum bool) *Roman {
+	return &Roman{lenient: lenient, useVinculum: useVinculum}
+}
+
+// max is the biggest number that can be written with the current settings
+func (r *Roman) max() int {
+	if r.useVinculum {
+		return 3999999
+	}
+	return 3999
+}
+
+// CanParseFromMachine determines if the input is a number between 1 and 3999
+// (or 3999999 when using the vinculum)
+func (r *Roman) CanParseFromMachine(s string) (bool, error) {
+	if !isMachineNumber(s) {
+		return false, ErrNotANumber
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n > r.max() {
+		return false, ErrTooLarge
+	}
+
+	if n < 1 {
+		return false, ErrTooSmall
+	}
+
+	return true, nil
+}
+
+// CanParseIntoMachine determines if the input is a Roman numeral. Unless the
+// parser is lenient, the numeral has to be in canonical form
+func (r *Roman) CanParseIntoMachine(s string) (bool, error) {
+	if _, err := r.parse(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DoFromMachine converts the number into a Roman numeral
+func (r *Roman) DoFromMachine(s string) (string, error) {
+	if ok, err := r.CanParseFromMachine(s); !ok {
+		return "", err
+	}
+
+	n, _ := strconv.Atoi(s)
+
+	// Without the vinculum (or when it's not needed) the thousands are written
+	// with `M`s so there's nothing special to do
+	if n < 4000 {
+		return toRoman(n), nil
+	}
+
+	var out strings.Builder
+	for _, c := range toRoman(n / 1000) {
+		out.WriteRune(c)
+		out.WriteRune(vinculum)
+	}
+	out.WriteString(toRoman(n % 1000))
+
+	return out.String(), nil
+}
+
+// DoIntoMachine converts the Roman numeral into a number
+func (r *Roman) DoIntoMachine(s string) (string, error) {
+	n, err := r.parse(s)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(n), nil
+}
+
+// Lossless is true, every number in the range has a numeral
+func (r *Roman) Lossless() bool {
+	return true
+}
+
+// parse gives back the value of the numeral making sure that it is written
+// the way the parser's settings allow
+func (r *Roman) parse(s string) (int, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return 0, ErrNotARomanNumeral
+	}
+
+	// Split the numeral in the part that has a vinculum on top of it and the
+	// part that doesn't. The overlined part always has to come first
+	var high, low []rune
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		if _, ok := romanValues[runes[i]]; !ok {
+			return 0, ErrNotARomanNumeral
+		}
+
+		if i+1 < len(runes) && runes[i+1] == vinculum {
+			if !r.useVinculum || len(low) > 0 {
+				return 0, ErrNotARomanNumeral
+			}
+			high = append(high, runes[i])
+			i++
+			continue
+		}
+		low = append(low, runes[i])
+	}
+
+	n := sumRoman(high)*1000 + sumRoman(low)
+	if n < 1 {
+		return 0, ErrNotARomanNumeral
+	}
+	if n > r.max() {
+		return 0, ErrTooLarge
+	}
+
+	if r.lenient {
+		return n, nil
+	}
+
+	// The canonical form is whatever we'd output for the same number
+	if canonical, _ := r.DoFromMachine(strconv.Itoa(n)); canonical != s {
+		return 0, ErrNotCanonical
+	}
+
+	return n, nil
+}
+
+// toRoman writes the number using the standard subtractive notation
+func toRoman(n int) string {
+	var out strings.Builder
+	for _, v := range romanSymbols {
+		for n >= v.value {
+			out.WriteString(v.symbol)
+			n -= v.value
+		}
+	}
+	return out.String()
+}
+
+// sumRoman adds up the numerals, a numeral that is smaller than the one
+// following it gets subtracted instead. This is lenient on purpose so that
+// inputs like `IIII` or `VX` still come out with a value
+func sumRoman(numerals []rune) int {
+	total := 0
+	for i, c := range numerals {
+		v := romanValues[c]
+		if i+1 < len(numerals) && v < romanValues[numerals[i+1]] {
+			total -= v
+		} else {
+			total += v
+		}
+	}
+	return total
+}
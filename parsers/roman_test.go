@@ -0,0 +1,150 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestRomanCanParseFromMachine(t *testing.T) {
+	tests := []struct {
+		vinculum bool
+		in       string
+		out      bool
+		err      error
+	}{
+		{false, "abc", false, ErrNotANumber},
+		{false, "MCM", false, ErrNotANumber},
+		{false, "0", false, ErrTooSmall},
+		{false, "1", true, nil},
+		{false, "3999", true, nil},
+		{false, "4000", false, ErrTooLarge},
+		{true, "4000", true, nil},
+		{true, "3999999", true, nil},
+		{true, "4000000", false, ErrTooLarge},
+	}
+
+	for i, tt := range tests {
+		roman := NewRoman(false, tt.vinculum)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := roman.CanParseFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestRomanCanParseIntoMachine(t *testing.T) {
+	tests := []struct {
+		lenient  bool
+		vinculum bool
+		in       string
+		out      bool
+		err      error
+	}{
+		// Nonsense
+		{false, false, "", false, ErrNotARomanNumeral},
+		{false, false, "ABC", false, ErrNotARomanNumeral},
+		{false, false, "1994", false, ErrNotARomanNumeral},
+		// Canonical
+		{false, false, "MCMXCIV", true, nil},
+		{false, false, "mcmxciv", true, nil},
+		{false, false, "MMMCMXCIX", true, nil},
+		// Non canonical
+		{false, false, "IIII", false, ErrNotCanonical},
+		{false, false, "VX", false, ErrNotCanonical},
+		{false, false, "IC", false, ErrNotCanonical},
+		{false, false, "MMMM", false, ErrTooLarge},
+		{true, false, "IIII", true, nil},
+		{true, false, "VX", true, nil},
+		// Vinculum
+		{false, false, "V̅", false, ErrNotARomanNumeral},
+		{false, true, "V̅", true, nil},
+		{false, true, "I̅V̅", true, nil},
+		{false, true, "MMMM", false, ErrNotCanonical},
+		// The overlined numerals have to come first
+		{false, true, "IV̅", false, ErrNotARomanNumeral},
+	}
+
+	for i, tt := range tests {
+		roman := NewRoman(tt.lenient, tt.vinculum)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := roman.CanParseIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestRomanDoFromMachine(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+		err error
+	}{
+		{"1", "I", nil},
+		{"4", "IV", nil},
+		{"9", "IX", nil},
+		{"14", "XIV", nil},
+		{"40", "XL", nil},
+		{"90", "XC", nil},
+		{"400", "CD", nil},
+		{"1994", "MCMXCIV", nil},
+		{"2024", "MMXXIV", nil},
+		{"3999", "MMMCMXCIX", nil},
+		{"4000", "I̅V̅", nil},
+		{"5001", "V̅I", nil},
+		{"1994000", "M̅C̅M̅X̅C̅I̅V̅", nil},
+		{"0", "", ErrTooSmall},
+	}
+
+	roman := NewRoman(false, true)
+	for i, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := roman.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestRomanDoIntoMachine(t *testing.T) {
+	tests := []struct {
+		lenient bool
+		in      string
+		out     string
+		err     error
+	}{
+		{false, "I", "1", nil},
+		{false, "XIV", "14", nil},
+		{false, "MCMXCIV", "1994", nil},
+		{false, "V̅I", "5001", nil},
+		{false, "IIII", "", ErrNotCanonical},
+		{true, "IIII", "4", nil},
+		{true, "VX", "5", nil},
+		{true, "MDCCCCX", "1910", nil},
+	}
+
+	for i, tt := range tests {
+		roman := NewRoman(tt.lenient, true)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := roman.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
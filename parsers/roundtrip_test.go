@@ -0,0 +1,178 @@
+package parsers
+
+import (
+	"strconv"
+	"testing"
+	"testing/quick"
+)
+
+// roundTrip checks that reading the output of DoFromMachine back with
+// DoIntoMachine gives the input, for the machine inputs gen makes out of
+// random numbers
+func roundTrip(t *testing.T, p Parser, gen func(n uint64) string) {
+	t.Helper()
+	if !p.Lossless() {
+		t.Fatalf("Given = `%T` ; want a lossless parser", p)
+	}
+
+	check := func(n uint64) bool {
+		in := gen(n)
+		out, err := p.DoFromMachine(in)
+		if err != nil {
+			t.Logf("Given = `%s` ; DoFromMachine failed with `%v`", in, err)
+			return false
+		}
+		back, err := p.DoIntoMachine(out)
+		if err != nil || back != in {
+			t.Logf("Given = `%s` ; want `%s` ; got `%s` (through `%s`, `%v`)", in, in, back, out, err)
+			return false
+		}
+		return true
+	}
+
+	if err := quick.Check(check, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
+
+// below keeps the random numbers in [min, max)
+func below(min, max uint64) func(uint64) string {
+	return func(n uint64) string {
+		return strconv.FormatUint(min+n%(max-min), 10)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	exactSize := func(units string) *Size {
+		s := NewSize(units)
+		s.SetExact(true)
+		return s
+	}
+	exactWord := NewNumberWord()
+	exactWord.SetExact(true)
+	exactCompact := NewNumberCompact("si", 1)
+	exactCompact.SetExact(true)
+	exactRate := NewRate("iec", "")
+	exactRate.SetExact(true)
+	usd, _ := NewMoney("USD", "", "", nil)
+	jpy, _ := NewMoney("JPY", "", "de", nil)
+	tcp, _ := BuiltinBitSchema("tcp")
+
+	tests := []struct {
+		name   string
+		parser Parser
+		gen    func(uint64) string
+	}{
+		{"group", NewNumberGroup(), below(0, 1<<63)},
+		{"roman", NewRoman(false, false), below(1, 4000)},
+		{"roman vinculum", NewRoman(false, true), below(1, 4000000)},
+		{"base 16", NewBase(16, 0, -1), below(0, 1<<63)},
+		{"base 2", NewBase(2, 0, -1), below(0, 1<<63)},
+		{"base 36", NewBase(36, 0, -1), below(0, 1<<63)},
+		{"duration short", NewDuration("s", "short"), below(0, 1<<33)},
+		{"duration long", NewDuration("s", "long"), below(0, 1<<33)},
+		{"duration ns", NewDuration("ns", "short"), below(0, 1<<62)},
+		{"money", usd, below(0, 1<<50)},
+		{"money locale", jpy, below(0, 1<<50)},
+		// A value without any named flags is written as a number, which is the
+		// machine side of things, so SYN is always set
+		{"bits", NewBits(tcp), func(n uint64) string { return strconv.FormatUint(n|0x02, 10) }},
+		{"size exact iec", exactSize("iec"), below(1000, 1<<63)},
+		{"size exact si", exactSize("si"), below(1000, 1<<63)},
+		{"number word exact", exactWord, below(1000, 1<<63)},
+		{"number compact exact", exactCompact, below(1000, 1<<63)},
+		{"rate exact", exactRate, below(0, 1<<50)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			roundTrip(t, tt.parser, tt.gen)
+		})
+	}
+}
+
+// TestRoundedTrip checks that the rounded output of lossy parsers reads back
+// as the nearest value it stands for
+func TestRoundedTrip(t *testing.T) {
+	tests := []struct {
+		parser Parser
+		in     string
+		out    string
+	}{
+		{NewNumberWord(), "1950000", "2000000"},
+		{NewNumberWord(), "1999999", "2000000"},
+		{NewNumberWord(), "1949999", "1900000"},
+		{NewNumberWord(), "999950000", "1000000000"},
+		{NewNumberWord(), "1234567", "1200000"},
+	}
+
+	for i, tt := range tests {
+		out, err := tt.parser.DoFromMachine(tt.in)
+		if err != nil {
+			t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, nil, err)
+			continue
+		}
+		got, err := tt.parser.DoIntoMachine(out)
+		if got != tt.out || err != nil {
+			t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s` (through `%s`, `%v`)", i, tt.in, tt.out, got, out, err)
+		}
+	}
+}
+
+func TestLossy(t *testing.T) {
+	ratio, _ := NewRatio("", "")
+	eur, _ := NewMoney("USD", "EUR", "", nil)
+	tests := []struct {
+		name   string
+		parser Parser
+	}{
+		{"size", NewSize("iec")},
+		{"number word", NewNumberWord()},
+		{"number compact", NewNumberCompact("short", 1)},
+		{"rate", NewRate("si", "")},
+		{"duration approx", NewDuration("s", "approx")},
+		{"bits without schema", NewBits(nil)},
+		{"ratio", ratio},
+		{"money converted", eur},
+		{"base with bits", NewBase(16, 8, -1)},
+	}
+
+	for i, tt := range tests {
+		if tt.parser.Lossless() {
+			t.Errorf("Case %d: Given = `%s` ; want lossy ; got lossless", i, tt.name)
+		}
+	}
+}
+
+func TestExact(t *testing.T) {
+	size := NewSize("iec")
+	size.SetExact(true)
+	word := NewNumberWord()
+	word.SetExact(true)
+	compact := NewNumberCompact("short", 1)
+	compact.SetExact(true)
+
+	tests := []struct {
+		parser Parser
+		in     string
+		out    string
+	}{
+		{size, "1610625024", "1.5Gi + 12288B"},
+		{size, "1610612736", "1.5Gi"},
+		{size, "1126", "1.0Ki + 102B"},
+		{word, "1234567", "1.234567 million"},
+		{word, "1000", "1 thousand"},
+		{compact, "1234567", "1.234567M"},
+	}
+
+	for i, tt := range tests {
+		got, err := tt.parser.DoFromMachine(tt.in)
+		if got != tt.out {
+			t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+		}
+		if err != nil {
+			t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, nil, err)
+		}
+	}
+}
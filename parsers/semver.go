@@ -0,0 +1,766 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNotAVersion error = errors.New("Not a version or a version constraint, ie: 1.2.3, ^1.2.3, >=1 <2 || 3.x or ~=1.4")
+var ErrUnknownSyntax error = errors.New("Unknown constraint syntax, use one of: npm, cargo, pep440")
+var ErrNotExpressible error = errors.New("The constraint can't be written in that syntax, only npm has `or` (||)")
+
+// semverSyntaxes are the constraint syntaxes we know about
+var semverSyntaxes = []string{"npm", "cargo", "pep440"}
+
+// pepPreReleases are PEP 440's pre-release spellings and what they're called
+var pepPreReleases = map[string]string{
+	"a":       "alpha",
+	"alpha":   "alpha",
+	"b":       "beta",
+	"beta":    "beta",
+	"c":       "release candidate",
+	"rc":      "release candidate",
+	"pre":     "release candidate",
+	"preview": "release candidate",
+}
+
+var semverPattern = regexp.MustCompile(`^[vV=]?(\d+|[xX*])(?:\.(\d+|[xX*]))?(?:\.(\d+|[xX*]))?(?:-([0-9A-Za-z.-]+))?(?:\+([0-9A-Za-z.-]+))?$`)
+var pepPattern = regexp.MustCompile(`(?i)^v?(?:(\d+)!)?(\d+(?:\.\d+)*)(\.\*)?(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?(\d*))?(?:[-_.]?(post|rev|r)[-_.]?(\d*)|-(\d+))?(?:[-_.]?(dev)[-_.]?(\d*))?(?:\+([a-z0-9.]+))?$`)
+var goPseudoPattern = regexp.MustCompile(`^v(\d+)\.(\d+)\.(\d+)-(?:(.+)\.)?(\d{14})-([0-9a-f]{12})(\+incompatible)?$`)
+
+// semVersion is a version as it's written in a constraint, parts that were
+// left out or written as a wildcard (ie: 1.x) aren't in `release`
+type semVersion struct {
+	release []int
+	// pre is the pre-release with its separator, ie: -beta.1 or rc1
+	pre string
+	// wild is PEP 440's trailing .* (ie: ==1.4.*)
+	wild bool
+}
+
+// String writes the version with at least major, minor and patch
+func (v semVersion) String() string {
+	parts := make([]string, len(v.release))
+	for i, n := range v.release {
+		parts[i] = strconv.Itoa(n)
+	}
+	for len(parts) < 3 {
+		parts = append(parts, "0")
+	}
+	return strings.Join(parts, ".") + v.pre
+}
+
+// padded fills in the parts that were left out with zeros
+func (v semVersion) padded() semVersion {
+	release := append([]int{}, v.release...)
+	for len(release) < 3 {
+		release = append(release, 0)
+	}
+	return semVersion{release: release, pre: v.pre}
+}
+
+// bump gives back the next version after the part at `i`, ie: the bump of
+// 1.2.3 at 1 is 1.3.0
+func (v semVersion) bump(i int) semVersion {
+	release := append([]int{}, v.padded().release[:i+1]...)
+	release[i]++
+	return semVersion{release: release}.padded()
+}
+
+// compareVersions is -1, 0 or 1 when a is before, the same or after b. Pre
+// releases come before the release and are compared one dot separated
+// identifier at a time
+func compareVersions(a, b semVersion) int {
+	a, b = a.padded(), b.padded()
+	for len(a.release) < len(b.release) {
+		a.release = append(a.release, 0)
+	}
+	for len(b.release) < len(a.release) {
+		b.release = append(b.release, 0)
+	}
+	for i := range a.release {
+		if a.release[i] != b.release[i] {
+			return compareInts(a.release[i], b.release[i])
+		}
+	}
+
+	switch {
+	case a.pre == b.pre:
+		return 0
+	case a.pre == "":
+		return 1
+	case b.pre == "":
+		return -1
+	}
+
+	ap := strings.Split(strings.TrimLeft(a.pre, "-."), ".")
+	bp := strings.Split(strings.TrimLeft(b.pre, "-."), ".")
+	for i := 0; i < len(ap) && i < len(bp); i++ {
+		an, aerr := strconv.Atoi(ap[i])
+		bn, berr := strconv.Atoi(bp[i])
+		switch {
+		case aerr == nil && berr == nil && an != bn:
+			return compareInts(an, bn)
+		case aerr == nil && berr != nil:
+			return -1
+		case aerr != nil && berr == nil:
+			return 1
+		case ap[i] < bp[i]:
+			return -1
+		case ap[i] > bp[i]:
+			return 1
+		}
+	}
+	return compareInts(len(ap), len(bp))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// versionBound is one end of a range, a nil version means there's no end
+type versionBound struct {
+	v         *semVersion
+	inclusive bool
+}
+
+// versionRange is the versions between two bounds minus the ones in `not`
+type versionRange struct {
+	lo, hi versionBound
+	not    []string
+	// none is set when nothing can be in the range, ie: >*
+	none bool
+}
+
+// atLeast raises the lower bound, a bound that's lower than the current one
+// changes nothing
+func (r *versionRange) atLeast(v semVersion, inclusive bool) {
+	if r.lo.v != nil {
+		c := compareVersions(v, *r.lo.v)
+		if c < 0 || (c == 0 && inclusive) {
+			return
+		}
+	}
+	r.lo = versionBound{&v, inclusive}
+}
+
+// atMost lowers the upper bound, a bound that's higher than the current one
+// changes nothing
+func (r *versionRange) atMost(v semVersion, inclusive bool) {
+	if r.hi.v != nil {
+		c := compareVersions(v, *r.hi.v)
+		if c > 0 || (c == 0 && inclusive) {
+			return
+		}
+	}
+	r.hi = versionBound{&v, inclusive}
+}
+
+// exactly is the only version in the range
+func (r *versionRange) exactly(v semVersion) {
+	r.atLeast(v, true)
+	r.atMost(v, true)
+}
+
+// empty determines if no version can be in the range
+func (r versionRange) empty() bool {
+	if r.none {
+		return true
+	}
+	if r.lo.v == nil || r.hi.v == nil {
+		return false
+	}
+	c := compareVersions(*r.lo.v, *r.hi.v)
+	return c > 0 || (c == 0 && !(r.lo.inclusive && r.hi.inclusive))
+}
+
+// isExact determines if only one version is in the range
+func (r versionRange) isExact() bool {
+	return r.lo.v != nil && r.hi.v != nil && r.lo.inclusive && r.hi.inclusive && compareVersions(*r.lo.v, *r.hi.v) == 0
+}
+
+// explain writes the range in words, ie: >=0.2.3 and <0.3.0
+func (r versionRange) explain() string {
+	if r.empty() {
+		return "no version"
+	}
+
+	var parts []string
+	if r.isExact() {
+		parts = append(parts, "exactly "+r.lo.v.String())
+	} else {
+		if r.lo.v != nil {
+			parts = append(parts, boundOp(">", r.lo.inclusive)+r.lo.v.String())
+		}
+		if r.hi.v != nil {
+			parts = append(parts, boundOp("<", r.hi.inclusive)+r.hi.v.String())
+		}
+	}
+	for _, not := range r.not {
+		parts = append(parts, "not "+not)
+	}
+
+	if len(parts) == 0 {
+		return "any version"
+	}
+	return strings.Join(parts, " and ")
+}
+
+func boundOp(op string, inclusive bool) string {
+	if inclusive {
+		return op + "="
+	}
+	return op
+}
+
+// Semver explains versions and version constraints. The machine side of
+// things is what package managers take, the human side is what it means, ie:
+// 	^0.2.3         <-> >=0.2.3 and <0.3.0
+// 	>=1 <2 || 3.x  ->  >=1.0.0 and <2.0.0 or >=3.0.0 and <4.0.0
+// 	~=1.4.2        ->  >=1.4.2 and <1.5.0
+// 	1.2.3-beta.1   ->  major 1, minor 2, patch 3, pre-release beta.1 ...
+// 	v0.0.0-<date>-<commit> -> Go pseudo-version for the commit from the date
+//
+// Constraints are read as npm, Cargo or PEP 440 (pip) wrote them, when the
+// syntax isn't given it's guessed from the operators. Going into the machine
+// side of things phrases are understood, ie: any 1.x after 1.4 -> ^1.4.0
+type Semver struct {
+	// syntax is one of `semverSyntaxes`, an empty string means guess it when
+	// explaining and use npm when writing constraints
+	syntax string
+}
+
+// NewSemver constructs a Semver parser
+func NewSemver(syntax string) (*Semver, error) {
+	syntax = strings.ToLower(syntax)
+	if syntax != "" && !contains(semverSyntaxes, syntax) {
+		return nil, ErrUnknownSyntax
+	}
+	return &Semver{syntax: syntax}, nil
+}
+
+// CanParseFromMachine determines if the input is a version or a constraint
+func (s *Semver) CanParseFromMachine(in string) (bool, error) {
+	if _, err := s.DoFromMachine(in); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CanParseIntoMachine determines if the input is a phrase describing versions
+func (s *Semver) CanParseIntoMachine(in string) (bool, error) {
+	if _, err := s.DoIntoMachine(in); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DoFromMachine explains a version, a Go pseudo-version or a constraint. A
+// version on its own is only read as a constraint when the syntax was given
+func (s *Semver) DoFromMachine(in string) (string, error) {
+	in = strings.TrimSpace(in)
+
+	if out, ok := describeGoPseudoVersion(in); ok {
+		return out, nil
+	}
+
+	if s.syntax == "" {
+		if out, ok := describeVersion(in); ok {
+			return out, nil
+		}
+	}
+
+	ranges, err := parseConstraint(in, s.syntax)
+	if err != nil {
+		return "", err
+	}
+
+	explained := make([]string, len(ranges))
+	for i, r := range ranges {
+		explained[i] = r.explain()
+	}
+	return strings.Join(explained, " or "), nil
+}
+
+// DoIntoMachine writes the constraint a phrase describes in the parser's
+// syntax, ie: any 1.x after 1.4 -> ^1.4.0
+func (s *Semver) DoIntoMachine(in string) (string, error) {
+	ranges, err := parseVersionPhrase(in)
+	if err != nil {
+		return "", err
+	}
+
+	syntax := s.syntax
+	if syntax == "" {
+		syntax = "npm"
+	}
+	if len(ranges) > 1 && syntax != "npm" {
+		return "", ErrNotExpressible
+	}
+
+	written := make([]string, len(ranges))
+	for i, r := range ranges {
+		written[i] = writeConstraint(r, syntax)
+	}
+	return strings.Join(written, " || "), nil
+}
+
+// Lossless is false, versions and constraints are only described
+func (s *Semver) Lossless() bool {
+	return false
+}
+
+// describeVersion explains a full SemVer or PEP 440 version
+func describeVersion(s string) (string, bool) {
+	if m := semverPattern.FindStringSubmatch(s); m != nil && m[3] != "" && !strings.ContainsAny(m[1]+m[2]+m[3], "xX*") && !strings.HasPrefix(s, "=") {
+		release := m[1] + "." + m[2] + "." + m[3]
+		parts := []string{"major " + m[1], "minor " + m[2], "patch " + m[3]}
+		if m[4] != "" {
+			parts = append(parts, fmt.Sprintf("pre-release %s (comes before %s)", m[4], release))
+		}
+		switch {
+		case m[5] == "incompatible":
+			parts = append(parts, "+incompatible (a v2 or later Go module without a go.mod)")
+		case m[5] != "":
+			parts = append(parts, fmt.Sprintf("build %s (ignored when comparing)", m[5]))
+		}
+		if m[1] == "0" {
+			parts = append(parts, "initial development (anything may change)")
+		}
+		return strings.Join(parts, ", "), true
+	}
+
+	m := pepPattern.FindStringSubmatch(s)
+	if m == nil || m[3] != "" || m[1]+m[4]+m[6]+m[8]+m[9]+m[11] == "" {
+		// Plain numbers with dots are left to the constraints (ie: 1.2 is
+		// any 1.2.x) and to other formats (ie: 10.0.0.1)
+		return "", false
+	}
+
+	var parts []string
+	if m[1] != "" {
+		parts = append(parts, "epoch "+m[1])
+	}
+	parts = append(parts, "release "+m[2])
+	if m[4] != "" {
+		parts = append(parts, fmt.Sprintf("%s %s (comes before %s)", pepPreReleases[strings.ToLower(m[4])], orZero(m[5]), m[2]))
+	}
+	if m[6] != "" || m[8] != "" {
+		parts = append(parts, fmt.Sprintf("post-release %s (comes after %s)", orZero(m[7]+m[8]), m[2]))
+	}
+	if m[9] != "" {
+		parts = append(parts, fmt.Sprintf("development release %s (comes before everything else in %s)", orZero(m[10]), m[2]))
+	}
+	if m[11] != "" {
+		parts = append(parts, "local version "+m[11])
+	}
+	return strings.Join(parts, ", "), true
+}
+
+func orZero(s string) string {
+	if s == "" {
+		return "0"
+	}
+	return s
+}
+
+// describeGoPseudoVersion explains the versions Go makes up for commits that
+// aren't tagged, ie: v0.0.0-20191109021931-daa7c04131f5
+func describeGoPseudoVersion(s string) (string, bool) {
+	m := goPseudoPattern.FindStringSubmatch(s)
+	if m == nil {
+		return "", false
+	}
+
+	tm, err := time.Parse("20060102150405", m[5])
+	if err != nil {
+		return "", false
+	}
+
+	patch, _ := strconv.Atoi(m[3])
+	var base string
+	switch {
+	case m[4] == "" && m[2] == "0" && m[3] == "0":
+		base = "no earlier tagged version"
+	case m[4] == "0" && patch > 0:
+		base = fmt.Sprintf("after v%s.%s.%d", m[1], m[2], patch-1)
+	case strings.HasSuffix(m[4], ".0"):
+		base = fmt.Sprintf("after v%s.%s.%s-%s", m[1], m[2], m[3], strings.TrimSuffix(m[4], ".0"))
+	default:
+		return "", false
+	}
+
+	out := fmt.Sprintf("Go pseudo-version for commit %s from %s, %s", m[6], tm.Format(time.RFC1123), base)
+	if m[7] != "" {
+		out += ", +incompatible (a v2 or later module without a go.mod)"
+	}
+	return out, true
+}
+
+// guessSyntax picks the syntax from the operators: PEP 440 has ==, != and ~=
+// (and versions like 1.0rc1), Cargo separates with commas and everything else
+// is npm
+func guessSyntax(s string) string {
+	switch {
+	case regexp.MustCompile(`~=|==|!=|\d(?:a|b|rc|\.post|\.dev)\d`).MatchString(s):
+		return "pep440"
+	case strings.Contains(s, ","):
+		return "cargo"
+	}
+	return "npm"
+}
+
+// parseConstraint reads the constraint into the ranges it allows, any of which
+// can match
+func parseConstraint(s, syntax string) ([]versionRange, error) {
+	if s == "" {
+		return nil, ErrNotAVersion
+	}
+	if syntax == "" {
+		syntax = guessSyntax(s)
+	}
+
+	switch syntax {
+	case "pep440":
+		r := versionRange{}
+		for _, clause := range strings.Split(s, ",") {
+			if err := applyPepClause(&r, strings.TrimSpace(clause)); err != nil {
+				return nil, err
+			}
+		}
+		return []versionRange{r}, nil
+	case "cargo":
+		r := versionRange{}
+		for _, clause := range strings.Split(s, ",") {
+			if err := applyNpmClause(&r, strings.TrimSpace(clause), "^"); err != nil {
+				return nil, err
+			}
+		}
+		return []versionRange{r}, nil
+	}
+
+	var ranges []versionRange
+	for _, alternative := range strings.Split(s, "||") {
+		r := versionRange{}
+		alternative = strings.TrimSpace(alternative)
+
+		if m := regexp.MustCompile(`^(\S+)\s+-\s+(\S+)$`).FindStringSubmatch(alternative); m != nil {
+			if err := applyNpmClause(&r, ">="+m[1], ""); err != nil {
+				return nil, err
+			}
+			if err := applyNpmClause(&r, "<="+m[2], ""); err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, r)
+			continue
+		}
+
+		// Operators can be written apart from their version, ie: >= 1.2
+		alternative = regexp.MustCompile(`([<>=~^])\s+`).ReplaceAllString(alternative, "$1")
+		for _, clause := range strings.Fields(alternative) {
+			if err := applyNpmClause(&r, clause, ""); err != nil {
+				return nil, err
+			}
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// applyNpmClause narrows the range with an npm (or Cargo) comparator, `bare`
+// is the operator used when there's none. Partial versions cover all the
+// versions they leave out, ie: <=1.2 is <1.3.0
+func applyNpmClause(r *versionRange, clause, bare string) error {
+	m := regexp.MustCompile(`^(\^|~>|~|>=|<=|>|<|=)?(.+)$`).FindStringSubmatch(clause)
+	if m == nil {
+		return ErrNotAVersion
+	}
+	v, err := parseSemVersion(m[2])
+	if err != nil {
+		return err
+	}
+
+	op := m[1]
+	if op == "" {
+		op = bare
+	}
+	full := len(v.release) == 3
+	given := len(v.release)
+
+	if given == 0 {
+		switch op {
+		case ">", "<":
+			r.none = true
+		}
+		return nil
+	}
+
+	switch op {
+	case "", "=":
+		if full {
+			r.exactly(v)
+		} else {
+			r.atLeast(v.padded(), true)
+			r.atMost(v.bump(given-1), false)
+		}
+	case ">":
+		if full {
+			r.atLeast(v, false)
+		} else {
+			r.atLeast(v.bump(given-1), true)
+		}
+	case ">=":
+		r.atLeast(v.padded(), true)
+	case "<":
+		r.atMost(v.padded(), false)
+	case "<=":
+		if full {
+			r.atMost(v, true)
+		} else {
+			r.atMost(v.bump(given-1), false)
+		}
+	case "~", "~>":
+		r.atLeast(v.padded(), true)
+		r.atMost(v.bump(min(given, 2)-1), false)
+	case "^":
+		r.atLeast(v.padded(), true)
+		r.atMost(v.bump(caretPart(v)), false)
+	}
+	return nil
+}
+
+// caretPart is the part a caret range can't change: the first one that isn't
+// zero, or the last one given
+func caretPart(v semVersion) int {
+	for i, n := range v.release {
+		if n != 0 || i == len(v.release)-1 {
+			return i
+		}
+	}
+	return 0
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// applyPepClause narrows the range with a PEP 440 version specifier
+func applyPepClause(r *versionRange, clause string) error {
+	m := regexp.MustCompile(`^(~=|===|==|!=|>=|<=|>|<)\s*(.+)$`).FindStringSubmatch(clause)
+	if m == nil {
+		return ErrNotAVersion
+	}
+	v, err := parsePepVersion(m[2])
+	if err != nil {
+		return err
+	}
+
+	switch m[1] {
+	case "~=":
+		if len(v.release) < 2 || v.wild {
+			return ErrNotAVersion
+		}
+		r.atLeast(v, true)
+		r.atMost(v.bump(len(v.release)-2), false)
+	case "==", "===":
+		if v.wild {
+			r.atLeast(v.padded(), true)
+			r.atMost(v.bump(len(v.release)-1), false)
+		} else {
+			r.exactly(v)
+		}
+	case "!=":
+		if v.wild {
+			r.not = append(r.not, strings.TrimSuffix(m[2], ".*")+".*")
+		} else {
+			r.not = append(r.not, v.String())
+		}
+	case ">=":
+		r.atLeast(v, true)
+	case ">":
+		r.atLeast(v, false)
+	case "<=":
+		r.atMost(v, true)
+	case "<":
+		r.atMost(v, false)
+	}
+	return nil
+}
+
+// parseSemVersion reads a possibly partial SemVer version, ie: 1.2.3-beta,
+// 1.2, 1.x, *
+func parseSemVersion(s string) (semVersion, error) {
+	m := semverPattern.FindStringSubmatch(s)
+	if m == nil {
+		return semVersion{}, ErrNotAVersion
+	}
+
+	v := semVersion{}
+	for _, part := range m[1:4] {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		v.release = append(v.release, n)
+	}
+	if m[4] != "" {
+		v.pre = "-" + m[4]
+	}
+	return v, nil
+}
+
+// parsePepVersion reads a PEP 440 version, the epoch and local version are
+// left out since they don't change which versions are close to each other
+func parsePepVersion(s string) (semVersion, error) {
+	m := pepPattern.FindStringSubmatch(s)
+	if m == nil {
+		return semVersion{}, ErrNotAVersion
+	}
+
+	v := semVersion{wild: m[3] != ""}
+	for _, part := range strings.Split(m[2], ".") {
+		n, _ := strconv.Atoi(part)
+		v.release = append(v.release, n)
+	}
+	if m[4] != "" {
+		v.pre = strings.ToLower(m[4]) + orZero(m[5])
+	}
+	return v, nil
+}
+
+// versionPhrases are the ways of saying where a range starts or ends, in the
+// npm operator they're written with
+var versionPhrases = []struct {
+	pattern *regexp.Regexp
+	op      string
+}{
+	{regexp.MustCompile(`^(?:after|since|from|starting (?:at|from|with)|at least|no (?:older|earlier) than)\s+`), ">="},
+	{regexp.MustCompile(`^(?:newer|later|greater|higher) than\s+|^above\s+`), ">"},
+	{regexp.MustCompile(`^(?:before|below|under|(?:older|earlier|less|lower) than)\s+`), "<"},
+	{regexp.MustCompile(`^(?:up to|at most|until|through|no (?:newer|later) than)\s+`), "<="},
+	{regexp.MustCompile(`^(?:exactly|only|just)\s+`), "="},
+	{regexp.MustCompile(`^compatible with\s+`), "^"},
+	{regexp.MustCompile(`^(?:patch(?:es| releases)?|(?:bug ?)?fixes) (?:of|for|to)\s+`), "~"},
+	{regexp.MustCompile(`^(?:any|every|all)(?: versions?)?(?: of)?\s+`), ""},
+}
+
+// parseVersionPhrase reads a phrase into the ranges it describes, ie: any 1.x
+// after 1.4, between 1.2 and 1.4 or 2.x. Phrases go through the same rules as
+// npm's operators, ie: up to 1.4 is <=1.4 which includes all of 1.4.x
+func parseVersionPhrase(s string) ([]versionRange, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if !regexp.MustCompile(`[a-z]{2,}`).MatchString(regexp.MustCompile(`\bv\d`).ReplaceAllString(s, "")) {
+		// Versions and constraints aren't phrases
+		return nil, ErrNotAVersion
+	}
+
+	if regexp.MustCompile(`^(?:any(?:thing| version)?|every version|latest)$`).MatchString(s) {
+		return []versionRange{{}}, nil
+	}
+
+	// `or later` isn't an alternative, it's where the range starts
+	s = regexp.MustCompile(`(\S+),? or (?:later|newer|above|higher|greater|up)`).ReplaceAllString(s, "at least $1")
+	s = regexp.MustCompile(`(\S+),? or (?:earlier|older|below|lower)`).ReplaceAllString(s, "up to $1")
+	s = regexp.MustCompile(`between (\S+) and (\S+)`).ReplaceAllString(s, "at least $1 up to $2")
+
+	version := regexp.MustCompile(`^v?(\d+(?:\.(?:\d+|x|\*))*)`)
+	filler := regexp.MustCompile(`^(?:,|and|but|versions?|releases?)\s*`)
+
+	var ranges []versionRange
+	for _, alternative := range regexp.MustCompile(`\s+or\s+|\s*\|\|\s*`).Split(s, -1) {
+		r := versionRange{}
+		rest := strings.TrimSpace(alternative)
+		clauses := 0
+
+		for rest != "" {
+			if m := filler.FindString(rest); m != "" {
+				rest = rest[len(m):]
+				continue
+			}
+
+			op := ""
+			for _, phrase := range versionPhrases {
+				if m := phrase.pattern.FindString(rest); m != "" {
+					op = phrase.op
+					rest = rest[len(m):]
+					break
+				}
+			}
+
+			m := version.FindStringSubmatch(rest)
+			if m == nil {
+				return nil, ErrNotAVersion
+			}
+			rest = strings.TrimSpace(rest[len(m[0]):])
+
+			if err := applyNpmClause(&r, op+m[1], ""); err != nil {
+				return nil, err
+			}
+			clauses++
+		}
+
+		if clauses == 0 {
+			return nil, ErrNotAVersion
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// writeConstraint writes the range in the syntax, using caret and tilde (or
+// PEP 440's compatible release) when they mean the same thing
+func writeConstraint(r versionRange, syntax string) string {
+	ops := map[string]map[string]string{
+		"npm":    {"exact": "", "caret": "^", "tilde": "~", "join": " "},
+		"cargo":  {"exact": "=", "caret": "^", "tilde": "~", "join": ", "},
+		"pep440": {"exact": "==", "join": ","},
+	}[syntax]
+
+	switch {
+	case r.empty():
+		return "<0.0.0-0"
+	case r.lo.v == nil && r.hi.v == nil:
+		if syntax == "pep440" {
+			return ">=0"
+		}
+		return "*"
+	case r.isExact():
+		return ops["exact"] + r.lo.v.String()
+	}
+
+	if r.lo.v != nil && r.hi.v != nil && r.lo.inclusive && !r.hi.inclusive && r.lo.v.pre == "" {
+		lo, hi := *r.lo.v, *r.hi.v
+		switch {
+		case syntax != "pep440" && compareVersions(hi, lo.bump(caretPart(lo))) == 0:
+			return ops["caret"] + lo.String()
+		case syntax != "pep440" && compareVersions(hi, lo.bump(1)) == 0:
+			return ops["tilde"] + lo.String()
+		case syntax == "pep440" && lo.release[2] == 0 && compareVersions(hi, lo.bump(0)) == 0:
+			return fmt.Sprintf("~=%d.%d", lo.release[0], lo.release[1])
+		case syntax == "pep440" && compareVersions(hi, lo.bump(1)) == 0:
+			return "~=" + lo.String()
+		}
+	}
+
+	var parts []string
+	if r.lo.v != nil {
+		parts = append(parts, boundOp(">", r.lo.inclusive)+r.lo.v.String())
+	}
+	if r.hi.v != nil {
+		parts = append(parts, boundOp("<", r.hi.inclusive)+r.hi.v.String())
+	}
+	return strings.Join(parts, ops["join"])
+}
@@ -0,0 +1,106 @@
+package parsers
+
+import "testing"
+
+func TestSemverDoFromMachine(t *testing.T) {
+	tests := []struct {
+		syntax string
+		in     string
+		out    string
+		err    error
+	}{
+		// npm
+		{"", "^0.2.3", ">=0.2.3 and <0.3.0", nil},
+		{"", "^1.2.3", ">=1.2.3 and <2.0.0", nil},
+		{"", "^0.0.3", ">=0.0.3 and <0.0.4", nil},
+		{"", "~1.2", ">=1.2.0 and <1.3.0", nil},
+		{"", "~1", ">=1.0.0 and <2.0.0", nil},
+		{"", ">=1 <2 || 3.x", ">=1.0.0 and <2.0.0 or >=3.0.0 and <4.0.0", nil},
+		{"", ">= 1.2.3 < 1.5", ">=1.2.3 and <1.5.0", nil},
+		{"", "1.2.3 - 2.3", ">=1.2.3 and <2.4.0", nil},
+		{"", "<=1.2", "<1.3.0", nil},
+		{"", ">1.2", ">=1.3.0", nil},
+		{"", "=1.2.3", "exactly 1.2.3", nil},
+		{"", "*", "any version", nil},
+		{"", ">2 <1", "no version", nil},
+		{"npm", "1.2.3", "exactly 1.2.3", nil},
+		// Cargo
+		{"", "^1.2, <1.5", ">=1.2.0 and <1.5.0", nil},
+		{"cargo", "1.2.3", ">=1.2.3 and <2.0.0", nil},
+		// PEP 440
+		{"", "~=1.4.2", ">=1.4.2 and <1.5.0", nil},
+		{"", "~=1.4", ">=1.4.0 and <2.0.0", nil},
+		{"", "==1.4.*", ">=1.4.0 and <1.5.0", nil},
+		{"", ">=1.0,!=1.5.*,<2", ">=1.0.0 and <2.0.0 and not 1.5.*", nil},
+		{"", ">=1.0rc1, <2", ">=1.0.0rc1 and <2.0.0", nil},
+		// Versions
+		{"", "1.2.3-beta.1+build.5", "major 1, minor 2, patch 3, pre-release beta.1 (comes before 1.2.3), build build.5 (ignored when comparing)", nil},
+		{"", "v0.3.1", "major 0, minor 3, patch 1, initial development (anything may change)", nil},
+		{"", "v2.0.0+incompatible", "major 2, minor 0, patch 0, +incompatible (a v2 or later Go module without a go.mod)", nil},
+		{"", "1.0rc1", "release 1.0, release candidate 1 (comes before 1.0)", nil},
+		{"", "2.0.post1", "release 2.0, post-release 1 (comes after 2.0)", nil},
+		{"", "1!1.0.dev3", "epoch 1, release 1.0, development release 3 (comes before everything else in 1.0)", nil},
+		// Go pseudo-versions
+		{"", "v0.0.0-20191109021931-daa7c04131f5", "Go pseudo-version for commit daa7c04131f5 from Sat, 09 Nov 2019 02:19:31 UTC, no earlier tagged version", nil},
+		{"", "v1.2.4-0.20191109021931-daa7c04131f5", "Go pseudo-version for commit daa7c04131f5 from Sat, 09 Nov 2019 02:19:31 UTC, after v1.2.3", nil},
+		{"", "v1.2.3-pre.0.20191109021931-daa7c04131f5", "Go pseudo-version for commit daa7c04131f5 from Sat, 09 Nov 2019 02:19:31 UTC, after v1.2.3-pre", nil},
+		// Not versions
+		{"", "10.0.0.1", "", ErrNotAVersion},
+		{"", "hello", "", ErrNotAVersion},
+		{"pep440", "1.2", "", ErrNotAVersion},
+	}
+
+	for i, tt := range tests {
+		semver, _ := NewSemver(tt.syntax)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := semver.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestSemverDoIntoMachine(t *testing.T) {
+	tests := []struct {
+		syntax string
+		in     string
+		out    string
+		err    error
+	}{
+		{"", "any 1.x after 1.4", "^1.4.0", nil},
+		{"", "any 1.x after 1.4, before 1.7", ">=1.4.0 <1.7.0", nil},
+		{"", "between 1.2 and 1.4", ">=1.2.0 <1.5.0", nil},
+		{"", "1.4 or later", ">=1.4.0", nil},
+		{"", "compatible with 0.2.3", "^0.2.3", nil},
+		{"", "patches of 1.2.3", "~1.2.3", nil},
+		{"", "exactly 1.2.3", "1.2.3", nil},
+		{"", "newer than 1.4.1", ">1.4.1", nil},
+		{"", "any 1.x or 3.x", "^1.0.0 || ^3.0.0", nil},
+		{"", "anything", "*", nil},
+		{"cargo", "exactly 1.2.3", "=1.2.3", nil},
+		{"cargo", "between 1.2 and 1.4", ">=1.2.0, <1.5.0", nil},
+		{"cargo", "1.x or 3.x", "", ErrNotExpressible},
+		{"pep440", "any 1.x after 1.4", "~=1.4", nil},
+		{"pep440", "any 1.4.x after 1.4.2", "~=1.4.2", nil},
+		{"pep440", "between 1.2 and 1.4", ">=1.2.0,<1.5.0", nil},
+		{"", "1.x", "", ErrNotAVersion},
+		{"", "hello", "", ErrNotAVersion},
+	}
+
+	for i, tt := range tests {
+		semver, _ := NewSemver(tt.syntax)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := semver.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
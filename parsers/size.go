@@ -4,12 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
 var ErrUnknownSuffix error = errors.New("Unknown unit suffix")
+var ErrAmbiguousUnit error = errors.New("Ambiguous unit, use an uppercase B for bytes (MB) or a lowercase b for bits (Mb)")
 
 // Suffixes describes which symbols and names are allowed for a given unit
 // Note that all of these are case insensitive when it comes time to do the
@@ -21,26 +23,35 @@ var suffixes = map[string][]string{
 		"tera", "peta", "exa", "zetta", "yotta",
 	},
 	"iec": []string{
-		"b", "ki", "mi", "gi", "ti", "pi", "ei", "zi", "yi", "kibi", "mebi",
-		"gibi", "tebi", "pebi", "exbi", "zebi", "yobi",
+		"b", "k", "m", "g", "t", "p", "e", "z", "y", "ki", "mi", "gi", "ti",
+		"pi", "ei", "zi", "yi", "kib", "mib", "gib", "tib", "pib", "eib", "zib",
+		"yib", "kibi", "mebi", "gibi", "tebi", "pebi", "exbi", "zebi", "yobi",
 	},
 }
 
+// sizeUnitNames are the names of the prefixes in order of their power
+var sizeUnitNames = map[string][]string{
+	"si":  {"kilo", "mega", "giga", "tera", "peta", "exa", "zetta", "yotta"},
+	"iec": {"kibi", "mebi", "gibi", "tebi", "pebi", "exbi", "zebi", "yobi"},
+}
+
 // Size can convert numbers to and from bytes
 type Size struct {
 	// units refers to the suffixes and which standard is being used, SI or IEC.
 	// See the `trans` field for more info
 	units string
-	// unitSuffix refers to the `i` of `b` that makes up the full suffix when
-	// dealing with any size bigger than B
-	unitSuffix string
 	// base refers to mathematical base, eg: base 10 , base 2, base 8, etc.
 	base float64
-	// trans holds the translation mapping between the `length` of a number
-	// string and the corresponding calculation settings, like how to determine
-	// the exponent base, the suffix of the byte etc.
-	trans map[int]struct {
-		// suffix is one of B, Kb, Mb etc. depending on the units we're using
+	// target is the index in `trans` that all output should be written in, -1
+	// means pick the unit based on the magnitude of the number
+	target int
+	// exact writes the bytes lost to rounding after the size, ie: 1.5Gi + 12345B
+	exact bool
+	// trans holds the units from smallest to largest along with the
+	// corresponding calculation settings, like how to determine the exponent
+	// base, the suffix of the byte etc.
+	trans []struct {
+		// suffix is one of B, KB, MB etc. depending on the units we're using
 		// Internation System of Units (SI) or International Electrotechnical
 		// Commission (IEC)
 		//
@@ -82,87 +93,113 @@ func NewSize(input interface{}) *Size {
 	switch units {
 	case "si":
 		return &Size{
-			units:      units,
-			unitSuffix: "b",
-			base:       10.0,
-			trans: map[int]struct {
+			units:  units,
+			base:   10.0,
+			target: -1,
+			trans: []struct {
 				suffix string
 				power  float64
 			}{
-				0:  {"B", 0.0}, //@TODO should this be 1.0?
-				1:  {"B", 0.0},
-				2:  {"B", 0.0},
-				3:  {"B", 0.0},
-				4:  {"Kb", 3.0},
-				5:  {"Kb", 3.0},
-				6:  {"Kb", 3.0},
-				7:  {"Mb", 6.0},
-				8:  {"Mb", 6.0},
-				9:  {"Mb", 6.0},
-				10: {"Gb", 9.0},
-				11: {"Gb", 9.0},
-				12: {"Gb", 9.0},
-				13: {"Tb", 12.0},
-				14: {"Tb", 12.0},
-				15: {"Tb", 12.0},
-				16: {"Pb", 15.0},
-				17: {"Pb", 15.0},
-				18: {"Pb", 15.0},
-				19: {"Eb", 18.0},
-				20: {"Eb", 18.0},
-				21: {"Eb", 18.0},
-				22: {"Zb", 21.0},
-				23: {"Zb", 21.0},
-				24: {"Zb", 21.0},
-				25: {"Yb", 24.0},
-				26: {"Yb", 24.0},
-				27: {"Yb", 24.0},
+				{"B", 0.0},
+				{"KB", 3.0},
+				{"MB", 6.0},
+				{"GB", 9.0},
+				{"TB", 12.0},
+				{"PB", 15.0},
+				{"EB", 18.0},
+				{"ZB", 21.0},
+				{"YB", 24.0},
 			},
 		}
 	case "iec":
 		fallthrough
 	default:
 		return &Size{
-			units:      units,
-			unitSuffix: "i",
-			base:       2.0,
-			trans: map[int]struct {
+			units:  units,
+			base:   2.0,
+			target: -1,
+			trans: []struct {
 				suffix string
 				power  float64
 			}{
-				0:  {"B", 0.0},
-				1:  {"B", 0.0},
-				2:  {"B", 0.0},
-				3:  {"B", 0.0},
-				4:  {"Ki", 10.0},
-				5:  {"Ki", 10.0},
-				6:  {"Ki", 10.0},
-				7:  {"Mi", 20.0},
-				8:  {"Mi", 20.0},
-				9:  {"Mi", 20.0},
-				10: {"Gi", 30.0},
-				11: {"Gi", 30.0},
-				12: {"Gi", 30.0},
-				13: {"Ti", 40.0},
-				14: {"Ti", 40.0},
-				15: {"Ti", 40.0},
-				16: {"Pi", 50.0},
-				17: {"Pi", 50.0},
-				18: {"Pi", 50.0},
-				19: {"Ei", 60.0},
-				20: {"Ei", 60.0},
-				21: {"Ei", 60.0},
-				22: {"Zi", 70.0},
-				23: {"Zi", 70.0},
-				24: {"Zi", 70.0},
-				25: {"Yi", 80.0},
-				26: {"Yi", 80.0},
-				27: {"Yi", 80.0},
+				{"B", 0.0},
+				{"Ki", 10.0},
+				{"Mi", 20.0},
+				{"Gi", 30.0},
+				{"Ti", 40.0},
+				{"Pi", 50.0},
+				{"Ei", 60.0},
+				{"Zi", 70.0},
+				{"Yi", 80.0},
 			},
 		}
 	}
 }
 
+// SetTarget forces the output to always be written in the given unit (ie:
+// `MiB`, `GB`, `K`, `gibibytes`) instead of picking one based on the magnitude
+// of the number, which is what "auto" (or an empty string) does.
+//
+// Just like when parsing the input, a unit with an `i` is IEC and a unit with
+// a prefix and a `B` is SI so the parser switches its units if needed. A bare
+// prefix (ie: `K`) uses whatever units the parser already had
+func (sz *Size) SetTarget(to string) error {
+	if to == "" || to == "auto" {
+		sz.target = -1
+		return nil
+	}
+
+	units, power := sz.units, -1
+
+	r := regexp.MustCompile(`^([kKmMgGtTpPeEzZyY])?(i)?(B)?$`)
+	if match := r.FindStringSubmatch(to); match != nil && (match[1] != "" || match[2] == "") {
+		prefix, binary, b := match[1], match[2] != "", match[3] != ""
+		power = 0
+		if prefix != "" {
+			power = strings.Index("kmgtpezy", strings.ToLower(prefix)) + 1
+		}
+		if binary {
+			units = "iec"
+		} else if prefix != "" && b {
+			units = "si"
+		}
+	}
+
+	// Names, ie: mebi, megabytes
+	lower := strings.ToLower(to)
+	for u, names := range sizeUnitNames {
+		for i, name := range append([]string{""}, names...) {
+			for _, word := range []string{"", "byte", "bytes"} {
+				if name+word != "" && lower == name+word {
+					power = i
+					if i > 0 {
+						units = u
+					}
+				}
+			}
+		}
+	}
+
+	if power < 0 {
+		return ErrUnknownSuffix
+	}
+
+	if units != sz.units {
+		exact := sz.exact
+		*sz = *NewSize(units)
+		sz.exact = exact
+	}
+	sz.target = power
+
+	return nil
+}
+
+// SetExact makes the output read back into the same amount of bytes, the bytes
+// lost to rounding are added at the end, ie: 1.5Gi + 12345B
+func (sz *Size) SetExact(exact bool) {
+	sz.exact = exact
+}
+
 // CanParseFromMachine determines if string is valid for this parser
 func (sz *Size) CanParseFromMachine(s string) (bool, error) {
 	if match, _ := regexp.MatchString(`[a-zA-Z]+`, s); match {
@@ -178,97 +215,223 @@ func (sz *Size) CanParseFromMachine(s string) (bool, error) {
 
 // CanParseIntoMachine determines if the user input can be handled by this parser.
 // The gist is that it should allow any number followed by a known suffix,
-// with no spaces in between the number of the suffix, ie:
+// optionally separated by spaces, ie:
 // 	1234654<suffix>
+// 	1,024 <suffix>
+// 	1.5 <suffix>
+//
+// See `lookupSuffix` for the suffixes that are understood. Sizes can be added
+// up with a +, ie: 1.5Gi + 12345B
 func (sz *Size) CanParseIntoMachine(s string) (bool, error) {
-	// Get the suffix passed
-	_, inputSuffix, err := getInputComponents(s)
-	if err != nil {
-		return false, err
-	}
-
-	// Does this suffix correspond to the units we're using?
-	allowed := false
-	for _, v := range suffixes[sz.units] {
-		if v == strings.ToLower(inputSuffix) {
-			allowed = true
-			break
+	for _, term := range strings.Split(s, "+") {
+		// Get the suffix passed
+		_, inputSuffix, err := getInputComponents(term)
+		if err != nil {
+			return false, err
 		}
-	}
 
-	if !allowed {
-		return false, ErrUnknownSuffix
+		// Does this suffix correspond to the units we're using?
+		if _, _, err := sz.lookupSuffix(inputSuffix); err != nil {
+			return false, err
+		}
 	}
 
 	return true, nil
 }
 
+// DoFromMachine writes the amount of bytes using the biggest unit that keeps
+// the number at or above 1, unless a target unit was set.
+//
+// A number that would round up to 4 digits (ie: 1000.0Ki) is written in the
+// next unit up instead, (ie: 1.0Mi)
 func (sz *Size) DoFromMachine(s string) (string, error) {
-	opts := sz.trans[len(s)]
-
 	n, err := strconv.ParseFloat(s, 64)
 	if err != nil {
 		return "", err
 	}
 
+	idx := sz.target
+	if idx < 0 {
+		idx = 0
+		for idx+1 < len(sz.trans) && n >= math.Pow(sz.base, sz.trans[idx+1].power) {
+			idx++
+		}
+
+		if idx+1 < len(sz.trans) && math.Round(n/math.Pow(sz.base, sz.trans[idx].power)*10)/10 >= 1000 {
+			idx++
+		}
+	}
+
+	opts := sz.trans[idx]
+	if sz.exact {
+		if out, ok := sz.exactSize(s, idx); ok {
+			return out, nil
+		}
+	}
+
 	denominator := math.Pow(sz.base, opts.power)
 	res := n / denominator
 
 	return fmt.Sprintf("%.1f%s", res, opts.suffix), nil
 }
 
-func (sz *Size) DoIntoMachine(s string) (string, error) {
-	// Pull out the number and the suffix from the string
-	num, suffix, err := getInputComponents(s)
-	if err != nil {
-		return "", err
+// exactSize writes the size in the unit rounded down to 1 decimal, followed by
+// the bytes that leaves out. Only whole amounts of bytes can be written that
+// way
+func (sz *Size) exactSize(s string, idx int) (string, bool) {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok || n.Sign() < 0 {
+		return "", false
 	}
 
-	// Figure out which lookup to use.
-	// When looking up the suffixes we only care about the first letter since the
-	// second one is always an `i` or an `b` depending on the units.
-	//
-	// Here the outlier is `b` since it's only 1 letter
-	suffix = strings.Title(strings.ToLower(suffix))
-	if suffix != "B" && len(suffix) == 1 {
-		suffix = suffix + sz.unitSuffix
+	units := "iec"
+	if sz.base == 10 {
+		units = "si"
 	}
+	multiplier := sizeMultiplier(units, idx).Num()
+	suffix := sz.trans[idx].suffix
 
-	var lookup struct {
-		suffix string
-		power  float64
+	tenths := new(big.Int).Quo(new(big.Int).Mul(n, big.NewInt(10)), multiplier)
+	whole, decimal := new(big.Int).QuoRem(tenths, big.NewInt(10), new(big.Int))
+	out := whole.String() + "." + decimal.String() + suffix
+
+	// The bytes left are whatever reading the rounded size back leaves out,
+	// that way the two always add up
+	covered, _ := sz.DoIntoMachine(out)
+	c, _ := new(big.Int).SetString(covered, 10)
+	if rest := new(big.Int).Sub(n, c); rest.Sign() > 0 {
+		out += " + " + rest.String() + "B"
+	}
+	return out, true
+}
+
+// DoIntoMachine gives back the amount of bytes, when the input is in bits the
+// result is rounded to the nearest byte. Sizes added up with a + are each
+// rounded before they're added, the same way they're written in exact mode
+func (sz *Size) DoIntoMachine(s string) (string, error) {
+	total := new(big.Int)
+	for _, term := range strings.Split(s, "+") {
+		// Pull out the number and the suffix from the string
+		num, suffix, err := getInputComponents(term)
+		if err != nil {
+			return "", err
+		}
+
+		multiplier, bits, err := sz.lookupSuffix(suffix)
+		if err != nil {
+			return "", err
+		}
+
+		res := new(big.Rat).Mul(num, multiplier)
+		if bits {
+			res.Quo(res, big.NewRat(8, 1))
+		}
+
+		n, _ := new(big.Int).SetString(res.FloatString(0), 10)
+		total.Add(total, n)
+	}
+
+	return total.String(), nil
+}
+
+// Lossless is only true in exact mode, otherwise sizes are rounded to 1
+// decimal
+func (sz *Size) Lossless() bool {
+	return sz.exact
+}
+
+// lookupSuffix figures out what the suffix multiplies the number by and if the
+// number is in bits rather than bytes.
+//
+// Symbols are case sensitive when it comes to the trailing `b`, following the
+// usual convention `B` are bytes and `b` are bits:
+// 	B, b           bytes, a lone `b` is a byte too
+// 	K, M, G...     bytes, the multiplier depends on the units being used
+// 	KB, MB, GB...  bytes (SI)
+// 	Kb, Mb, Gb...  bits (SI)
+// 	Ki, KiB...     bytes (IEC)
+// 	Kib, Mib...    bits (IEC)
+//
+// Just like with `SetTarget`, symbols with an `i` or a `B` say which units
+// they're in so they work regardless of the units being used. When both the
+// prefix and the `b` are lowercase (ie: `mb`) there's no telling which one the
+// user meant so we error out instead of guessing.
+//
+// Full names like "megabytes" or "gibibits" describe themselves as well
+func (sz *Size) lookupSuffix(suffix string) (*big.Rat, bool, error) {
+	lower := strings.ToLower(suffix)
+
+	// Full names
+	for _, units := range []string{"si", "iec"} {
+		for i, name := range append([]string{""}, sizeUnitNames[units]...) {
+			for _, word := range []string{"byte", "bytes", "bit", "bits"} {
+				if lower == name+word {
+					return sizeMultiplier(units, i), strings.HasPrefix(word, "bit"), nil
+				}
+			}
+		}
 	}
-	for _, v := range sz.trans {
-		if suffix == v.suffix {
-			lookup = v
-			break
+
+	// Names of the prefixes on their own (ie: kilo, mebi)
+	for units, names := range sizeUnitNames {
+		for i, name := range names {
+			if lower == name {
+				return sizeMultiplier(units, i+1), false, nil
+			}
 		}
 	}
 
-	// If we didn't find the suffix then we don't know how to handle it
-	if lookup.suffix == "" {
-		return "", ErrUnknownSuffix
+	r := regexp.MustCompile(`^([kKmMgGtTpPeEzZyY])?([iI])?([bB])?$`)
+	match := r.FindStringSubmatch(suffix)
+	if match == nil || (match[1] == "" && match[2] != "") {
+		return nil, false, ErrUnknownSuffix
 	}
+	prefix, binary, b := match[1], match[2] != "", match[3]
 
-	multiplier := math.Pow(sz.base, lookup.power)
-	res := num * multiplier
+	if prefix == "" {
+		return sizeMultiplier(sz.units, 0), false, nil
+	}
 
-	return fmt.Sprintf("%d", int(res)), nil
+	if b == "b" && prefix == strings.ToLower(prefix) {
+		return nil, false, ErrAmbiguousUnit
+	}
+
+	units := sz.units
+	if binary {
+		units = "iec"
+	} else if b != "" {
+		units = "si"
+	}
+	power := strings.Index("kmgtpezy", strings.ToLower(prefix)) + 1
+
+	return sizeMultiplier(units, power), b == "b", nil
+}
+
+// sizeMultiplier gives back what the number has to be multiplied by for the
+// given power of the units, ie: iec 1 is 1024 and si 1 is 1000
+func sizeMultiplier(units string, power int) *big.Rat {
+	base, exp := int64(1000), int64(power)
+	if units == "iec" {
+		base = 1024
+	}
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(base), big.NewInt(exp), nil))
 }
 
 // getInputComponents splits out the input string into the expected components:
-// 	the number
-// 	and the size suffix
-func getInputComponents(s string) (float64, string, error) {
-	r := regexp.MustCompile(`(?i)^([0-9]+)(\.[0-9]+)?([a-z]+)`)
-	match := r.FindStringSubmatch(s)
-
-	if len(match) != 4 {
-		return 0, "", ErrUnparsable
+// 	the number, which can be grouped with commas and have decimals
+// 	and the size suffix, which can be separated from the number with spaces
+func getInputComponents(s string) (*big.Rat, string, error) {
+	r := regexp.MustCompile(`(?i)^([0-9]{1,3}(,[0-9]{3})+|[0-9]+)(\.[0-9]+)?\s*([a-z]+)$`)
+	match := r.FindStringSubmatch(strings.TrimSpace(s))
+
+	if len(match) != 5 {
+		return nil, "", ErrUnparsable
+	}
+
+	num, ok := new(big.Rat).SetString(strings.Replace(match[1], ",", "", -1) + match[3])
+	if !ok {
+		return nil, "", ErrNotANumber
 	}
 
-	// we got decimal
-	num, err := strconv.ParseFloat(match[1]+match[2], 64)
-	suffix := match[3]
-	return num, suffix, err
+	return num, match[4], nil
 }
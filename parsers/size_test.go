@@ -164,6 +164,28 @@ func TestCanParseIntoMachineEdgeCases(t *testing.T) {
 		{"iec", "abv0ki", false, ErrUnparsable},
 		// It should only allow 1 decimal place
 		{"iec", "100.50.3ki", false, ErrUnparsable},
+		// Groups have to be of 3 digits
+		{"iec", "1,02 KiB", false, ErrUnparsable},
+		{"iec", "1,024 KiB", true, nil},
+		// Spaces are allowed between the number and the suffix
+		{"si", "5 GB", true, nil},
+		{"si", "5   GB", true, nil},
+		{"si", "5 G B", false, ErrUnparsable},
+		// Symbols with an i or a B say which units they're in
+		{"si", "5KiB", true, nil},
+		{"iec", "5KB", true, nil},
+		{"iec", "5 GB", true, nil},
+		{"iec", "1 Gb", true, nil},
+		// And so do full names
+		{"iec", "5 kilobytes", true, nil},
+		{"si", "5 gibibytes", true, nil},
+		{"si", "5 gigabits", true, nil},
+		{"si", "5 gigabats", false, ErrUnknownSuffix},
+		// Lowercase prefix and lowercase b could be bits or bytes
+		{"si", "5mb", false, ErrAmbiguousUnit},
+		{"iec", "5kib", false, ErrAmbiguousUnit},
+		{"si", "5Mb", true, nil},
+		{"si", "5mB", true, nil},
 	}
 
 	for i, tt := range tests {
@@ -190,31 +212,31 @@ func TestSizeDoFromMachine(t *testing.T) {
 		{"1", "1.0B", nil},
 		{"10", "10.0B", nil},
 		{"100", "100.0B", nil},
-		{"1000", "1.0Kb", nil},
-		{"10000", "10.0Kb", nil},
-		{"100000", "100.0Kb", nil},
-		{"1000000", "1.0Mb", nil},
-		{"10000000", "10.0Mb", nil},
-		{"100000000", "100.0Mb", nil},
-		{"1000000000", "1.0Gb", nil},
-		{"10000000000", "10.0Gb", nil},
-		{"100000000000", "100.0Gb", nil},
-		{"1000000000000", "1.0Tb", nil},
-		{"10000000000000", "10.0Tb", nil},
-		{"100000000000000", "100.0Tb", nil},
-		{"1000000000000000", "1.0Pb", nil},
-		{"10000000000000000", "10.0Pb", nil},
-		{"100000000000000000", "100.0Pb", nil},
-		{"1000000000000000000", "1.0Eb", nil},
-		{"10000000000000000000", "10.0Eb", nil},
-		{"100000000000000000000", "100.0Eb", nil},
-		{"1000000000000000000000", "1.0Zb", nil},
-		{"10000000000000000000000", "10.0Zb", nil},
-		{"100000000000000000000000", "100.0Zb", nil},
-		{"1000000000000000000000000", "1.0Yb", nil},
-		{"10000000000000000000000000", "10.0Yb", nil},
-		{"100000000000000000000000000", "100.0Yb", nil},
-		{"142089140826193550568923157", "142.1Yb", nil},
+		{"1000", "1.0KB", nil},
+		{"10000", "10.0KB", nil},
+		{"100000", "100.0KB", nil},
+		{"1000000", "1.0MB", nil},
+		{"10000000", "10.0MB", nil},
+		{"100000000", "100.0MB", nil},
+		{"1000000000", "1.0GB", nil},
+		{"10000000000", "10.0GB", nil},
+		{"100000000000", "100.0GB", nil},
+		{"1000000000000", "1.0TB", nil},
+		{"10000000000000", "10.0TB", nil},
+		{"100000000000000", "100.0TB", nil},
+		{"1000000000000000", "1.0PB", nil},
+		{"10000000000000000", "10.0PB", nil},
+		{"100000000000000000", "100.0PB", nil},
+		{"1000000000000000000", "1.0EB", nil},
+		{"10000000000000000000", "10.0EB", nil},
+		{"100000000000000000000", "100.0EB", nil},
+		{"1000000000000000000000", "1.0ZB", nil},
+		{"10000000000000000000000", "10.0ZB", nil},
+		{"100000000000000000000000", "100.0ZB", nil},
+		{"1000000000000000000000000", "1.0YB", nil},
+		{"10000000000000000000000000", "10.0YB", nil},
+		{"100000000000000000000000000", "100.0YB", nil},
+		{"142089140826193550568923157", "142.1YB", nil},
 	}
 
 	sizeP := NewSize("si")
@@ -245,28 +267,33 @@ func TestSizeDoFromMachineIEC(t *testing.T) {
 		{"1024", "1.0Ki", nil},
 		{"10000", "9.8Ki", nil},
 		{"100000", "97.7Ki", nil},
-		{"1000000", "1.0Mi", nil},
+		{"1000000", "976.6Ki", nil},
 		{"10000000", "9.5Mi", nil},
 		{"100000000", "95.4Mi", nil},
-		{"1000000000", "0.9Gi", nil},
+		{"1000000000", "953.7Mi", nil},
 		{"10000000000", "9.3Gi", nil},
 		{"100000000000", "93.1Gi", nil},
-		{"1000000000000", "0.9Ti", nil},
+		{"1000000000000", "931.3Gi", nil},
 		{"10000000000000", "9.1Ti", nil},
 		{"100000000000000", "90.9Ti", nil},
-		{"1000000000000000", "0.9Pi", nil},
+		{"1000000000000000", "909.5Ti", nil},
 		{"10000000000000000", "8.9Pi", nil},
 		{"100000000000000000", "88.8Pi", nil},
-		{"1000000000000000000", "0.9Ei", nil},
+		{"1000000000000000000", "888.2Pi", nil},
 		{"10000000000000000000", "8.7Ei", nil},
 		{"100000000000000000000", "86.7Ei", nil},
-		{"1000000000000000000000", "0.8Zi", nil},
+		{"1000000000000000000000", "867.4Ei", nil},
 		{"10000000000000000000000", "8.5Zi", nil},
 		{"100000000000000000000000", "84.7Zi", nil},
-		{"1000000000000000000000000", "0.8Yi", nil},
+		{"1000000000000000000000000", "847.0Zi", nil},
 		{"10000000000000000000000000", "8.3Yi", nil},
 		{"100000000000000000000000000", "82.7Yi", nil},
 		{"142089140826193550568923157", "117.5Yi", nil},
+		// Values just under a boundary go up a unit instead of showing 4 digits
+		{"1023999", "1.0Mi", nil},
+		{"1023948", "999.9Ki", nil},
+		// Past the last unit the number keeps growing
+		{"1208925819614629174706176000", "1000.0Yi", nil},
 	}
 
 	sizeP := NewSize("iec")
@@ -283,6 +310,54 @@ func TestSizeDoFromMachineIEC(t *testing.T) {
 	}
 }
 
+func TestSizeDoFromMachineWithTarget(t *testing.T) {
+	tests := []struct {
+		units string
+		to    string
+		in    string
+		out   string
+		err   error
+	}{
+		{"iec", "auto", "2097152", "2.0Mi", nil},
+		{"iec", "", "2097152", "2.0Mi", nil},
+		{"iec", "MiB", "1024", "0.0Mi", nil},
+		{"iec", "MiB", "1023999", "1.0Mi", nil},
+		{"iec", "Mi", "1073741824", "1024.0Mi", nil},
+		{"iec", "K", "1073741824", "1048576.0Ki", nil},
+		{"iec", "B", "1073741824", "1073741824.0B", nil},
+		{"si", "K", "1000000", "1000.0KB", nil},
+		{"si", "GB", "1000000", "0.0GB", nil},
+		// The target can switch the units
+		{"iec", "MB", "1000000", "1.0MB", nil},
+		{"si", "KiB", "1024", "1.0Ki", nil},
+		{"si", "gibibytes", "1073741824", "1.0Gi", nil},
+		{"iec", "megabytes", "1000000", "1.0MB", nil},
+		{"iec", "kilo", "1000", "1.0KB", nil},
+		// Unknown targets
+		{"iec", "MX", "1024", "", ErrUnknownSuffix},
+		{"iec", "Mb", "1024", "", ErrUnknownSuffix},
+		{"iec", "i", "1024", "", ErrUnknownSuffix},
+	}
+
+	for i, tt := range tests {
+		sizeP := NewSize(tt.units)
+		t.Run(tt.to+" "+tt.in, func(t *testing.T) {
+			err := sizeP.SetTarget(tt.to)
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.to, tt.err, err)
+			}
+			if err != nil {
+				return
+			}
+
+			got, _ := sizeP.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+		})
+	}
+}
+
 // This series is a bit different given that `NewSize` determines the input
 // type or standard to use based on the suffix that is passed to it.
 // These aren't checking that logic however (other tests do that)
@@ -307,8 +382,30 @@ func TestSizeDoIntoMachine(t *testing.T) {
 		{"iec", "10k", "10240", nil},
 		{"iec", "10000k", "10240000", nil},
 		{"si", "10000k", "10000000", nil},
-		{"si", "10000gb", "10000000000000", nil},
+		{"si", "10000GB", "10000000000000", nil},
 		{"iec", "1x", "", ErrUnknownSuffix},
+		// bits, rounded to the nearest byte
+		{"si", "1Kb", "125", nil},
+		{"si", "1Mb", "125000", nil},
+		{"iec", "1Kib", "128", nil},
+		{"iec", "1 Gb", "125000000", nil},
+		{"iec", "5 GB", "5000000000", nil},
+		{"si", "1KiB", "1024", nil},
+		{"si", "10000gb", "", ErrAmbiguousUnit},
+		// Spaces, groupings and decimals
+		{"si", "5 GB", "5000000000", nil},
+		{"iec", "1,024 KiB", "1048576", nil},
+		{"iec", "512M", "536870912", nil},
+		{"iec", "2.5T", "2748779069440", nil},
+		{"si", "1.5 gigabytes", "1500000000", nil},
+		// Full names don't depend on the units
+		{"iec", "1.5 gigabytes", "1500000000", nil},
+		{"si", "2 gibibytes", "2147483648", nil},
+		{"si", "3 bytes", "3", nil},
+		{"si", "1 byte", "1", nil},
+		{"si", "16 bits", "2", nil},
+		{"si", "1 megabit", "125000", nil},
+		{"iec", "1 Mebibits", "131072", nil},
 	}
 
 	for i, tt := range tests {
@@ -0,0 +1,494 @@
+package parsers
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNotATimestamp error = errors.New("Not a timestamp, ie: 1700000000, 2023-11-14T22:13:20Z or next tuesday at 5pm")
+var ErrUnknownTimezone error = errors.New("Unknown time zone, ie: UTC, Local or America/New_York")
+
+// timestampLayouts are the formats understood besides epochs, the ones
+// without a zone are read in the parser's location
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC1123,
+	time.RFC1123Z,
+	time.RFC850,
+	time.RFC822,
+	time.RFC822Z,
+	time.ANSIC,
+	time.UnixDate,
+	time.RubyDate,
+	"2006-01-02 15:04:05.999999999 -0700 MST", // Go's time.String()
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"02/Jan/2006:15:04:05 -0700", // Apache and nginx access logs
+	"2006/01/02 15:04:05.999999", // Go's log package
+	"Jan _2 15:04:05",            // syslog, which doesn't have the year
+	"2006-01-02",
+}
+
+// timestampEpochUnits are the units an epoch can be in, ordered by size. The
+// limit is the biggest (exclusive) epoch that is guessed to be in the unit,
+// which keeps seconds until the year 5138
+var timestampEpochUnits = []struct {
+	name  string
+	size  time.Duration
+	limit float64
+}{
+	{"s", time.Second, 1e11},
+	{"ms", time.Millisecond, 1e14},
+	{"us", time.Microsecond, 1e17},
+	{"ns", time.Nanosecond, 0},
+}
+
+// relativeUnits are used to write how far a time is from now, months and
+// years are nominal
+var relativeUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"week", 7 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// Timestamp converts points in time. The machine side of things is a Unix
+// epoch (or RFC3339 when asked), the human side can be written in one of these
+// styles:
+// 	absolute  Tue, 14 Nov 2023 22:13:20 UTC
+// 	relative  3 days ago, in 2 hours
+// 	calendar  yesterday at 10:13 PM, last Tuesday at 9:00 AM
+//
+// Epochs can be in seconds, milliseconds, microseconds or nanoseconds. When
+// the unit isn't given it's guessed from the magnitude of the number.
+//
+// Going into the machine side of things, besides RFC3339, RFC1123 and common
+// log file formats, English phrases are understood:
+// 	now, today, tomorrow at noon, next tuesday at 5pm, last friday
+// 	2 weeks ago, in 3 hours, 10 minutes from now
+type Timestamp struct {
+	// style is how the output is written: absolute, relative or calendar
+	style string
+	// unit is what the epoch is counting, an empty string means guess it
+	unit string
+	// to is what the machine side is written as: epoch or rfc3339
+	to string
+	// loc is the time zone used for the output and for inputs without one
+	loc *time.Location
+	// now is the clock used for anything relative to the current time
+	now func() time.Time
+}
+
+// NewTimestamp constructs a Timestamp parser. The style defaults to absolute,
+// `to` defaults to epoch, `loc` to the local time zone and `now` to the
+// system clock
+func NewTimestamp(style, unit, to string, loc *time.Location, now func() time.Time) *Timestamp {
+	if style != "relative" && style != "calendar" {
+		style = "absolute"
+	}
+
+	if to != "rfc3339" {
+		to = "epoch"
+	}
+
+	if unit == "µs" {
+		unit = "us"
+	}
+	if _, ok := durationMachineUnits[unit]; !ok {
+		unit = ""
+	}
+
+	if loc == nil {
+		loc = time.Local
+	}
+
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Timestamp{style: style, unit: unit, to: to, loc: loc, now: now}
+}
+
+// LoadTimezone finds the location by its IANA name, `Local` and `UTC` work
+// as well
+func LoadTimezone(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrUnknownTimezone
+	}
+	return loc, nil
+}
+
+// CanParseFromMachine determines if the input is an epoch or a timestamp in
+// one of the known layouts
+func (t *Timestamp) CanParseFromMachine(s string) (bool, error) {
+	if _, err := t.parseFromInput(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CanParseIntoMachine determines if the input is a timestamp in one of the
+// known layouts or an English phrase
+func (t *Timestamp) CanParseIntoMachine(s string) (bool, error) {
+	if _, err := t.parseIntoInput(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DoFromMachine writes the time in the parser's style
+func (t *Timestamp) DoFromMachine(s string) (string, error) {
+	tm, err := t.parseFromInput(s)
+	if err != nil {
+		return "", err
+	}
+
+	switch t.style {
+	case "relative":
+		return humanizeRelative(tm, t.now()), nil
+	case "calendar":
+		return humanizeCalendar(tm.In(t.loc), t.now().In(t.loc)), nil
+	default:
+		return tm.In(t.loc).Format(time.RFC1123), nil
+	}
+}
+
+// DoIntoMachine gives back the epoch (in the parser's unit, seconds when
+// there's none) or the RFC3339 timestamp
+func (t *Timestamp) DoIntoMachine(s string) (string, error) {
+	tm, err := t.parseIntoInput(s)
+	if err != nil {
+		return "", err
+	}
+
+	if t.to == "rfc3339" {
+		return tm.In(t.loc).Format(time.RFC3339Nano), nil
+	}
+
+	unit := time.Second
+	if t.unit != "" {
+		unit = durationMachineUnits[t.unit]
+	}
+
+	ns := new(big.Int).Mul(big.NewInt(tm.Unix()), big.NewInt(int64(time.Second)))
+	ns.Add(ns, big.NewInt(int64(tm.Nanosecond())))
+	return new(big.Rat).SetFrac(ns, big.NewInt(int64(unit))).FloatString(0), nil
+}
+
+// Lossless is false, relative times are rounded and epochs with more than
+// seconds are written down to the second
+func (t *Timestamp) Lossless() bool {
+	return false
+}
+
+// parseFromInput reads an epoch or a timestamp in one of the known layouts
+func (t *Timestamp) parseFromInput(s string) (time.Time, error) {
+	if tm, err := t.parseEpoch(s); err == nil || err != ErrNotATimestamp {
+		return tm, err
+	}
+	return t.parseLayouts(s)
+}
+
+// parseIntoInput reads a timestamp in one of the known layouts or an English
+// phrase
+func (t *Timestamp) parseIntoInput(s string) (time.Time, error) {
+	if tm, err := t.parseLayouts(s); err == nil {
+		return tm, nil
+	}
+	return t.parsePhrase(s)
+}
+
+// parseEpoch reads a number of seconds, milliseconds, microseconds or
+// nanoseconds since 1970-01-01 UTC, seconds can have decimals
+func (t *Timestamp) parseEpoch(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if !regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`).MatchString(s) {
+		return time.Time{}, ErrNotATimestamp
+	}
+
+	n, _ := new(big.Rat).SetString(s)
+	magnitude, _ := new(big.Rat).Abs(n).Float64()
+
+	unit := time.Nanosecond
+	for _, u := range timestampEpochUnits {
+		if u.name == t.unit || (t.unit == "" && (u.limit == 0 || magnitude < u.limit)) {
+			unit = u.size
+			break
+		}
+	}
+
+	ns := new(big.Rat).Mul(n, new(big.Rat).SetInt64(int64(unit)))
+	total := new(big.Int).Quo(ns.Num(), ns.Denom())
+	sec, nsec := new(big.Int).DivMod(total, big.NewInt(int64(time.Second)), new(big.Int))
+	if !sec.IsInt64() {
+		return time.Time{}, ErrTooLarge
+	}
+
+	return time.Unix(sec.Int64(), nsec.Int64()).UTC(), nil
+}
+
+// parseLayouts tries each of the known layouts, the ones without a year (ie:
+// syslog) are taken to be within the last year
+func (t *Timestamp) parseLayouts(s string) (time.Time, error) {
+	// Python's logging module (and others) separate the fraction of a second
+	// with a comma
+	s = regexp.MustCompile(`(\d{2}:\d{2}:\d{2}),(\d+)`).ReplaceAllString(strings.TrimSpace(s), "$1.$2")
+
+	for _, layout := range timestampLayouts {
+		tm, err := time.ParseInLocation(layout, s, t.loc)
+		if err != nil {
+			continue
+		}
+
+		if tm.Year() == 0 {
+			now := t.now().In(t.loc)
+			tm = tm.AddDate(now.Year(), 0, 0)
+			if tm.After(now.Add(24 * time.Hour)) {
+				tm = tm.AddDate(-1, 0, 0)
+			}
+		}
+
+		return tm, nil
+	}
+
+	return time.Time{}, ErrNotATimestamp
+}
+
+// parsePhrase reads times written in English relative to the parser's clock
+func (t *Timestamp) parsePhrase(s string) (time.Time, error) {
+	s = strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	now := t.now().In(t.loc)
+
+	if s == "now" || s == "right now" {
+		return now, nil
+	}
+
+	// Amounts of time from now, ie: 2 weeks ago, in 3 hours
+	sign, amount := 0, ""
+	switch {
+	case strings.HasSuffix(s, " ago"):
+		sign, amount = -1, strings.TrimSuffix(s, " ago")
+	case strings.HasSuffix(s, " from now"):
+		sign, amount = 1, strings.TrimSuffix(s, " from now")
+	case strings.HasPrefix(s, "in "):
+		sign, amount = 1, strings.TrimPrefix(s, "in ")
+	}
+
+	if sign != 0 {
+		return addRelative(now, amount, sign)
+	}
+
+	// Days with an optional time, ie: next tuesday at 5pm, tomorrow, at noon
+	day, clock := s, ""
+	if strings.HasPrefix(s, "at ") {
+		day, clock = "", s[3:]
+	} else if i := strings.LastIndex(s, " at "); i >= 0 {
+		day, clock = s[:i], s[i+4:]
+	} else if _, _, _, err := parseClock(s); err == nil {
+		day, clock = "", s
+	}
+
+	date, err := parseDay(day, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	hour, min, sec := 0, 0, 0
+	if clock != "" {
+		if hour, min, sec, err = parseClock(clock); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	return time.Date(date.Year(), date.Month(), date.Day(), hour, min, sec, 0, t.loc), nil
+}
+
+// addRelative moves the time by the amount, ie: "2 weeks", "3 months", "an
+// hour and a half". Months and years follow the calendar
+func addRelative(now time.Time, amount string, sign int) (time.Time, error) {
+	words := strings.Fields(amount)
+	if len(words) > 0 {
+		last := strings.TrimSuffix(words[len(words)-1], "s")
+		if last == "month" || last == "year" {
+			n, err := parseDurationQuantity(words[:len(words)-1])
+			if err != nil || n != float64(int(n)) {
+				return time.Time{}, ErrNotATimestamp
+			}
+			if last == "month" {
+				return now.AddDate(0, sign*int(n), 0), nil
+			}
+			return now.AddDate(sign*int(n), 0, 0), nil
+		}
+	}
+
+	d, err := parseDuration(amount)
+	if err != nil {
+		return time.Time{}, ErrNotATimestamp
+	}
+
+	return now.Add(time.Duration(sign) * d), nil
+}
+
+// parseDay finds the date the words refer to, ie: today, yesterday, next
+// tuesday, last friday, 2023-11-14. No words means today
+func parseDay(s string, now time.Time) (time.Time, error) {
+	switch s {
+	case "", "today", "tonight":
+		return now, nil
+	case "tomorrow":
+		return now.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return now.AddDate(0, 0, -1), nil
+	}
+
+	if tm, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return tm, nil
+	}
+
+	words := strings.Fields(s)
+	modifier, name := "", words[len(words)-1]
+	if len(words) == 2 {
+		modifier = words[0]
+	}
+	if len(words) > 2 || (modifier != "" && modifier != "next" && modifier != "last" && modifier != "this") {
+		return time.Time{}, ErrNotATimestamp
+	}
+
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		full := strings.ToLower(wd.String())
+		if name != full && name != full[:3] {
+			continue
+		}
+
+		// A weekday on its own (or with this/next) is the next one after
+		// today, with last it's the one before today
+		diff := (int(wd) - int(now.Weekday()) + 7) % 7
+		if modifier == "last" {
+			diff = diff - 7
+		} else if diff == 0 {
+			diff = 7
+		}
+		return now.AddDate(0, 0, diff), nil
+	}
+
+	return time.Time{}, ErrNotATimestamp
+}
+
+// parseClock reads the time of the day, ie: 5pm, 5:30 pm, 17:30, 17:30:15,
+// noon, midnight
+func parseClock(s string) (int, int, int, error) {
+	switch s {
+	case "noon", "midday":
+		return 12, 0, 0, nil
+	case "midnight":
+		return 0, 0, 0, nil
+	}
+
+	match := regexp.MustCompile(`^([0-9]{1,2})(?::([0-9]{2}))?(?::([0-9]{2}))? ?(am|pm|a\.m\.|p\.m\.)?$`).FindStringSubmatch(s)
+	if match == nil || (match[2] == "" && match[4] == "") {
+		return 0, 0, 0, ErrNotATimestamp
+	}
+
+	hour, _ := strconv.Atoi(match[1])
+	min, _ := strconv.Atoi(match[2])
+	sec, _ := strconv.Atoi(match[3])
+
+	if meridiem := strings.Replace(match[4], ".", "", -1); meridiem != "" {
+		if hour < 1 || hour > 12 {
+			return 0, 0, 0, ErrNotATimestamp
+		}
+		hour = hour % 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	}
+
+	if hour > 23 || min > 59 || sec > 59 {
+		return 0, 0, 0, ErrNotATimestamp
+	}
+
+	return hour, min, sec, nil
+}
+
+// humanizeRelative writes how far the time is from now rounded to its
+// largest unit, ie: 3 days ago, in 2 hours
+func humanizeRelative(tm, now time.Time) string {
+	d := tm.Sub(now)
+	future := d > 0
+	if d < 0 {
+		d = -d
+	}
+
+	if d < time.Second {
+		return "just now"
+	}
+
+	idx := 0
+	for d < relativeUnits[idx].size {
+		idx++
+	}
+
+	n := int64(math.Round(float64(d) / float64(relativeUnits[idx].size)))
+
+	// Rounding can push the value into the next unit, ie: 59m 50s is an hour
+	// rather than 60 minutes
+	if idx > 0 && time.Duration(n)*relativeUnits[idx].size >= relativeUnits[idx-1].size {
+		idx--
+		n = int64(math.Round(float64(d) / float64(relativeUnits[idx].size)))
+	}
+
+	amount := pluralize(n, relativeUnits[idx].name)
+	if n == 1 {
+		amount = "a " + relativeUnits[idx].name
+		if relativeUnits[idx].name == "hour" {
+			amount = "an hour"
+		}
+	}
+
+	if future {
+		return "in " + amount
+	}
+	return amount + " ago"
+}
+
+// humanizeCalendar writes the time in terms of the days around now, ie:
+// today at 3:04 PM, yesterday at 9:00 AM, last Tuesday at 5:00 PM. Anything
+// further than a week away gets its full date
+func humanizeCalendar(tm, now time.Time) string {
+	midnight := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+
+	// Rounding keeps days that are 23 or 25 hours long (daylight saving time)
+	// counting as one
+	days := int(math.Round(midnight(tm).Sub(midnight(now)).Hours() / 24))
+
+	clock := tm.Format("3:04 PM")
+	switch {
+	case days == 0:
+		return "today at " + clock
+	case days == 1:
+		return "tomorrow at " + clock
+	case days == -1:
+		return "yesterday at " + clock
+	case days > 1 && days < 7:
+		return tm.Weekday().String() + " at " + clock
+	case days < -1 && days > -7:
+		return "last " + tm.Weekday().String() + " at " + clock
+	}
+
+	return tm.Format("January 2, 2006 at 3:04 PM")
+}
@@ -0,0 +1,137 @@
+package parsers
+
+import (
+	"testing"
+	"time"
+)
+
+// fixedClock is Tuesday, 14 Nov 2023 22:13:20 UTC (1700000000)
+func fixedClock() time.Time {
+	return time.Unix(1700000000, 0).UTC()
+}
+
+func TestTimestampDoFromMachine(t *testing.T) {
+	tests := []struct {
+		style string
+		unit  string
+		in    string
+		out   string
+		err   error
+	}{
+		// Should guess the unit of the epoch
+		{"absolute", "", "1700000000", "Tue, 14 Nov 2023 22:13:20 UTC", nil},
+		{"absolute", "", "1700000000123", "Tue, 14 Nov 2023 22:13:20 UTC", nil},
+		{"absolute", "", "1700000000123456", "Tue, 14 Nov 2023 22:13:20 UTC", nil},
+		{"absolute", "", "1700000000123456789", "Tue, 14 Nov 2023 22:13:20 UTC", nil},
+		{"absolute", "", "1700000000.5", "Tue, 14 Nov 2023 22:13:20 UTC", nil},
+		{"absolute", "", "0", "Thu, 01 Jan 1970 00:00:00 UTC", nil},
+		{"absolute", "", "-86400", "Wed, 31 Dec 1969 00:00:00 UTC", nil},
+		{"absolute", "ms", "1700000000", "Tue, 20 Jan 1970 16:13:20 UTC", nil},
+		{"absolute", "s", "99999999999999999999999", "", ErrTooLarge},
+		// Should understand common layouts
+		{"absolute", "", "2023-11-14T22:13:20Z", "Tue, 14 Nov 2023 22:13:20 UTC", nil},
+		{"absolute", "", "2023-11-14T17:13:20-05:00", "Tue, 14 Nov 2023 22:13:20 UTC", nil},
+		{"absolute", "", "Tue, 14 Nov 2023 22:13:20 GMT", "Tue, 14 Nov 2023 22:13:20 UTC", nil},
+		{"absolute", "", "14/Nov/2023:22:13:20 +0000", "Tue, 14 Nov 2023 22:13:20 UTC", nil},
+		{"absolute", "", "2023-11-14 22:13:20,123", "Tue, 14 Nov 2023 22:13:20 UTC", nil},
+		{"absolute", "", "2023/11/14 22:13:20", "Tue, 14 Nov 2023 22:13:20 UTC", nil},
+		{"absolute", "", "Nov 14 22:13:20", "Tue, 14 Nov 2023 22:13:20 UTC", nil},
+		{"absolute", "", "Dec 24 10:00:00", "Sat, 24 Dec 2022 10:00:00 UTC", nil},
+		{"absolute", "", "yesterday", "", ErrNotATimestamp},
+		// Relative
+		{"relative", "", "1700000000", "just now", nil},
+		{"relative", "", "1699740800", "3 days ago", nil},
+		{"relative", "", "1700007200", "in 2 hours", nil},
+		{"relative", "", "1700003590", "in an hour", nil},
+		{"relative", "", "1699913600", "a day ago", nil},
+		{"relative", "", "1690000000", "4 months ago", nil},
+		{"relative", "", "1600000000", "3 years ago", nil},
+		// Calendar
+		{"calendar", "", "1699999200", "today at 10:00 PM", nil},
+		{"calendar", "", "1699920000", "today at 12:00 AM", nil},
+		{"calendar", "", "1699833600", "yesterday at 12:00 AM", nil},
+		{"calendar", "", "1700053200", "tomorrow at 1:00 PM", nil},
+		{"calendar", "", "1699642800", "last Friday at 7:00 PM", nil},
+		{"calendar", "", "1700247600", "Friday at 7:00 PM", nil},
+		{"calendar", "", "1600000000", "September 13, 2020 at 12:26 PM", nil},
+	}
+
+	for i, tt := range tests {
+		timestamp := NewTimestamp(tt.style, tt.unit, "", time.UTC, fixedClock)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := timestamp.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestTimestampDoFromMachineTimezone(t *testing.T) {
+	loc, err := LoadTimezone("America/New_York")
+	if err != nil {
+		t.Skip("time zone database isn't available")
+	}
+
+	timestamp := NewTimestamp("absolute", "", "", loc, fixedClock)
+	if got, _ := timestamp.DoFromMachine("1700000000"); got != "Tue, 14 Nov 2023 17:13:20 EST" {
+		t.Errorf("want `Tue, 14 Nov 2023 17:13:20 EST` ; got `%s`", got)
+	}
+
+	if _, err := LoadTimezone("Nowhere/Special"); err != ErrUnknownTimezone {
+		t.Errorf("want `%t` ; got `%t`", ErrUnknownTimezone, err)
+	}
+}
+
+func TestTimestampDoIntoMachine(t *testing.T) {
+	tests := []struct {
+		unit string
+		to   string
+		in   string
+		out  string
+		err  error
+	}{
+		{"", "", "2023-11-14T22:13:20Z", "1700000000", nil},
+		{"ms", "", "2023-11-14T22:13:20.5Z", "1700000000500", nil},
+		{"ns", "", "2023-11-14T22:13:20Z", "1700000000000000000", nil},
+		{"", "rfc3339", "Tue, 14 Nov 2023 22:13:20 UTC", "2023-11-14T22:13:20Z", nil},
+		{"", "", "now", "1700000000", nil},
+		{"", "", "today", "1699920000", nil},
+		{"", "", "tomorrow at noon", "1700049600", nil},
+		{"", "", "yesterday at 5:30pm", "1699896600", nil},
+		{"", "", "at 17:00", "1699981200", nil},
+		{"", "", "5pm", "1699981200", nil},
+		{"", "rfc3339", "next tuesday at 5pm", "2023-11-21T17:00:00Z", nil},
+		{"", "rfc3339", "tuesday", "2023-11-21T00:00:00Z", nil},
+		{"", "rfc3339", "this friday at 9 am", "2023-11-17T09:00:00Z", nil},
+		{"", "rfc3339", "last fri", "2023-11-10T00:00:00Z", nil},
+		{"", "rfc3339", "last tuesday", "2023-11-07T00:00:00Z", nil},
+		{"", "rfc3339", "2023-12-25 at midnight", "2023-12-25T00:00:00Z", nil},
+		{"", "", "2 weeks ago", "1698790400", nil},
+		{"", "", "in 3 hours", "1700010800", nil},
+		{"", "", "an hour and a half ago", "1699994600", nil},
+		{"", "", "10 minutes from now", "1700000600", nil},
+		{"", "rfc3339", "3 months ago", "2023-08-14T22:13:20Z", nil},
+		{"", "rfc3339", "in a year", "2024-11-14T22:13:20Z", nil},
+		{"", "", "in 1.5 months", "", ErrNotATimestamp},
+		{"", "", "next blursday", "", ErrNotATimestamp},
+		{"", "", "tomorrow at 25pm", "", ErrNotATimestamp},
+		{"", "", "1700000000", "", ErrNotATimestamp},
+	}
+
+	for i, tt := range tests {
+		timestamp := NewTimestamp("", tt.unit, tt.to, time.UTC, fixedClock)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := timestamp.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if err != tt.err {
+				t.Errorf("Case %d: Given = `%s` ; want `%t` ; got `%t`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
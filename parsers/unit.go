@@ -0,0 +1,464 @@
+package parsers
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrNotAQuantity error = errors.New("Not a quantity, ie: 5mi, 98.6F or 100 km/h")
+var ErrUnknownUnit error = errors.New("Unknown unit")
+var ErrBadUnitDefinition error = errors.New("Bad unit definition")
+
+// IncompatibleUnitsError is returned when the units measure different things,
+// ie: a length can't be converted into a mass
+type IncompatibleUnitsError struct {
+	From, To                   string
+	FromDimension, ToDimension string
+}
+
+func (e *IncompatibleUnitsError) Error() string {
+	return fmt.Sprintf("Can't convert %s (%s) into %s (%s)", e.From, e.FromDimension, e.To, e.ToDimension)
+}
+
+// builtinUnits are the units that are always known, see units.txt for the
+// format
+//go:embed units.txt
+var builtinUnits string
+
+// unitPrefix is an SI prefix, ie: k and kilo are 10^3
+type unitPrefix struct {
+	symbol, name string
+	exp          int
+}
+
+// smallUnitPrefixes are the SI prefixes under kilo, the rest come from the
+// ones sizes use (see `suffixes` and `sizeUnitNames`)
+var smallUnitPrefixes = []unitPrefix{
+	{"da", "deca", 1},
+	{"h", "hecto", 2},
+	{"d", "deci", -1},
+	{"c", "centi", -2},
+	{"m", "milli", -3},
+	{"µ", "micro", -6},
+	{"u", "micro", -6},
+	{"n", "nano", -9},
+	{"p", "pico", -12},
+	{"f", "femto", -15},
+}
+
+// unitPrefixes gives back all the SI prefixes. The symbols of the big ones
+// are uppercase (M is mega, m is milli) except for kilo
+func unitPrefixes() []unitPrefix {
+	prefixes := append([]unitPrefix{}, smallUnitPrefixes...)
+	for i, name := range sizeUnitNames["si"] {
+		symbol := strings.ToUpper(suffixes["si"][i+1])
+		if name == "kilo" {
+			symbol = "k"
+		}
+		prefixes = append(prefixes, unitPrefix{symbol, name, 3 * (i + 1)})
+	}
+	return prefixes
+}
+
+// unitDef is a unit in terms of the base units, a value in the unit is
+// (value + offset) * factor in the base units
+type unitDef struct {
+	factor float64
+	offset float64
+	// dims are the powers of each base dimension, ie: length/time is
+	// {length: 1, time: -1}
+	dims map[string]int
+	// prefixed units can have SI prefixes added to them
+	prefixed bool
+	// show is the unit values are converted into when none was asked for
+	show string
+	// symbol is the first name the unit was defined with, temperatures are
+	// always written with it (ie: C is shown as °C)
+	symbol string
+}
+
+// UnitTable holds the units that can be converted
+type UnitTable struct {
+	units map[string]unitDef
+	// bases are the symbols of the base units in the order they were defined
+	bases []struct{ dimension, symbol string }
+}
+
+// NewUnitTable constructs the table with the builtin units followed by the
+// definitions in `extra`, which use the same format and can redefine units
+func NewUnitTable(extra ...string) (*UnitTable, error) {
+	t := &UnitTable{units: map[string]unitDef{}}
+	for _, definitions := range append([]string{builtinUnits}, extra...) {
+		if err := t.define(definitions); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// define adds the definitions to the table, one per line
+func (t *UnitTable) define(definitions string) error {
+	for n, line := range strings.Split(definitions, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		bad := fmt.Errorf("%w: line %d: '%s'", ErrBadUnitDefinition, n+1, line)
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return bad
+		}
+		fields := strings.Fields(parts[1])
+		if len(fields) < 2 {
+			return bad
+		}
+
+		var def unitDef
+		rest := fields[2:]
+		if fields[0] == "base" {
+			def = unitDef{factor: 1, dims: map[string]int{fields[1]: 1}}
+		} else {
+			factor, err := parseUnitFactor(fields[0])
+			if err != nil {
+				return bad
+			}
+			of, err := t.parseExpr(fields[1])
+			if err != nil {
+				return bad
+			}
+			def = unitDef{factor: factor * of.factor, dims: of.dims}
+		}
+
+		for i := 0; i < len(rest); i++ {
+			switch {
+			case rest[i] == "prefixed":
+				def.prefixed = true
+			case rest[i] == "offset" && i+1 < len(rest):
+				offset, err := strconv.ParseFloat(rest[i+1], 64)
+				if err != nil {
+					return bad
+				}
+				def.offset = offset
+				i++
+			case rest[i] == "->" && i+1 < len(rest):
+				def.show = rest[i+1]
+				i++
+			default:
+				return bad
+			}
+		}
+
+		names := strings.Split(parts[0], ",")
+		def.symbol = strings.TrimSpace(names[0])
+		for i := range names {
+			names[i] = strings.TrimSpace(names[i])
+			t.units[names[i]] = def
+		}
+		if fields[0] == "base" {
+			t.bases = append(t.bases, struct{ dimension, symbol string }{fields[1], names[0]})
+		}
+	}
+	return nil
+}
+
+// parseUnitFactor reads a number or a fraction, ie: 5/9
+func parseUnitFactor(s string) (float64, error) {
+	parts := strings.SplitN(s, "/", 2)
+	n, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || len(parts) == 1 {
+		return n, err
+	}
+	d, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || d == 0 {
+		return 0, ErrBadUnitDefinition
+	}
+	return n / d, nil
+}
+
+// lookup finds a unit by name, with an SI prefix when the unit takes them.
+// Names are case sensitive (mm isn't Mm) unless nothing else matches
+func (t *UnitTable) lookup(name string) (unitDef, bool) {
+	if def, ok := t.units[name]; ok {
+		return def, true
+	}
+
+	for _, p := range unitPrefixes() {
+		for _, prefix := range []string{p.symbol, p.name} {
+			rest := strings.TrimPrefix(name, prefix)
+			def, ok := t.units[rest]
+			if rest == name || !ok || !def.prefixed {
+				continue
+			}
+			// Symbols go with symbols (km) and names with names (kilometer)
+			if (prefix == p.symbol) != (len(rest) < 4) {
+				continue
+			}
+			// A prefixed unit is shown like the unit it prefixes (kWh as J)
+			// or, when that one has nothing to show it in, as that unit
+			scaled := def
+			scaled.factor *= math.Pow10(p.exp)
+			scaled.symbol = ""
+			if scaled.show == "" {
+				scaled.show = rest
+			}
+			return scaled, true
+		}
+	}
+
+	for n, def := range t.units {
+		if strings.EqualFold(n, name) {
+			return def, true
+		}
+	}
+	return unitDef{}, false
+}
+
+// parseExpr reads a unit made out of other units, ie: km/h, kg*m/s^2, m²,
+// miles per hour. Everything after a / divides
+func (t *UnitTable) parseExpr(expr string) (unitDef, error) {
+	expr = strings.TrimSpace(expr)
+	expr = regexp.MustCompile(`\s+per\s+`).ReplaceAllString(expr, "/")
+	expr = regexp.MustCompile(`\s*([*/·⋅])\s*`).ReplaceAllString(expr, "$1")
+	expr = strings.NewReplacer(" ", "*", "·", "*", "⋅", "*", "²", "^2", "³", "^3").Replace(expr)
+	if expr == "" {
+		return unitDef{}, ErrUnknownUnit
+	}
+
+	result := unitDef{factor: 1, dims: map[string]int{}}
+	terms := 0
+	for i, part := range strings.Split(expr, "/") {
+		sign := 1
+		if i > 0 {
+			sign = -1
+		}
+		for _, term := range strings.Split(part, "*") {
+			m := regexp.MustCompile(`^(.+?)(?:\^(-?[0-9]+)|([0-9]+))?$`).FindStringSubmatch(term)
+			if m == nil {
+				return unitDef{}, ErrUnknownUnit
+			}
+			def, ok := t.lookup(m[1])
+			if !ok {
+				return unitDef{}, fmt.Errorf("%w: '%s'", ErrUnknownUnit, m[1])
+			}
+
+			exp := 1
+			if m[2]+m[3] != "" {
+				exp, _ = strconv.Atoi(m[2] + m[3])
+			}
+			exp *= sign
+
+			result.factor *= math.Pow(def.factor, float64(exp))
+			for dim, power := range def.dims {
+				result.dims[dim] += power * exp
+			}
+			if terms == 0 && exp == 1 {
+				result.offset, result.show, result.symbol = def.offset, def.show, def.symbol
+			}
+			terms++
+		}
+	}
+
+	// Offsets only make sense for a lone unit, ie: °C but not °C/min
+	if terms > 1 {
+		result.offset, result.show, result.symbol = 0, "", ""
+	}
+	return result, nil
+}
+
+// describeDims writes the dimensions, ie: length/time
+func (t *UnitTable) describeDims(dims map[string]int) string {
+	return t.writeDims(dims, func(b struct{ dimension, symbol string }) string { return b.dimension }, "dimensionless")
+}
+
+// baseUnit writes the dimensions in base units, ie: m/s
+func (t *UnitTable) baseUnit(dims map[string]int) string {
+	return t.writeDims(dims, func(b struct{ dimension, symbol string }) string { return b.symbol }, "")
+}
+
+func (t *UnitTable) writeDims(dims map[string]int, name func(struct{ dimension, symbol string }) string, none string) string {
+	var num, den []string
+	for _, b := range t.bases {
+		power := dims[b.dimension]
+		term := name(b)
+		if power > 1 || power < -1 {
+			term += "^" + strconv.Itoa(int(math.Abs(float64(power))))
+		}
+		switch {
+		case power > 0:
+			num = append(num, term)
+		case power < 0:
+			den = append(den, term)
+		}
+	}
+
+	switch {
+	case len(num) == 0 && len(den) == 0:
+		return none
+	case len(num) == 0:
+		num = []string{"1"}
+	}
+	if len(den) == 0 {
+		return strings.Join(num, "*")
+	}
+	return strings.Join(num, "*") + "/" + strings.Join(den, "*")
+}
+
+// sameDims determines if both units measure the same thing
+func sameDims(a, b map[string]int) bool {
+	for dim := range a {
+		if a[dim] != b[dim] {
+			return false
+		}
+	}
+	for dim := range b {
+		if a[dim] != b[dim] {
+			return false
+		}
+	}
+	return true
+}
+
+// Unit converts quantities between units of the same dimension, ie:
+// 	5mi      -> 8.04672 km
+// 	98.6F    -> 37°C
+// 	100 km/h -> 27.7778 m/s
+//
+// The unit to convert into can be given to the parser or with the quantity
+// (ie: 5 mi in km), otherwise the unit's usual counterpart is used (metric
+// for imperial and the other way around) or the base units. The machine side
+// of things is the number without its unit
+type Unit struct {
+	table *UnitTable
+	// to is the unit quantities are converted into, an empty string means
+	// pick one
+	to string
+}
+
+// NewUnit constructs a Unit parser, `to` has to be a known unit
+func NewUnit(to string, table *UnitTable) (*Unit, error) {
+	if to != "" {
+		if _, err := table.parseExpr(to); err != nil {
+			return nil, err
+		}
+	}
+	return &Unit{table: table, to: to}, nil
+}
+
+// CanParseFromMachine determines if the input is a quantity that can be
+// converted
+func (u *Unit) CanParseFromMachine(s string) (bool, error) {
+	if _, _, err := u.convert(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CanParseIntoMachine is the same as CanParseFromMachine since both sides are
+// quantities
+func (u *Unit) CanParseIntoMachine(s string) (bool, error) {
+	return u.CanParseFromMachine(s)
+}
+
+// DoFromMachine writes the converted quantity along with its unit
+func (u *Unit) DoFromMachine(s string) (string, error) {
+	value, unit, err := u.convert(s)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case unit == "":
+		return formatUnitValue(value), nil
+	case strings.HasPrefix(unit, "°"):
+		return formatUnitValue(value) + unit, nil
+	}
+	return formatUnitValue(value) + " " + unit, nil
+}
+
+// DoIntoMachine writes the converted quantity without its unit
+func (u *Unit) DoIntoMachine(s string) (string, error) {
+	value, _, err := u.convert(s)
+	if err != nil {
+		return "", err
+	}
+	return formatUnitValue(value), nil
+}
+
+// Lossless is false, quantities are written with 6 significant digits
+func (u *Unit) Lossless() bool {
+	return false
+}
+
+// convert reads the quantity and gives it back in the unit it's converted
+// into, along with that unit
+func (u *Unit) convert(s string) (float64, string, error) {
+	s = strings.TrimSpace(s)
+
+	to := u.to
+	if m := regexp.MustCompile(`^(.+)\s+(?:to|in|into|as)\s+(\S+)$`).FindStringSubmatch(s); m != nil {
+		s, to = m[1], m[2]
+	}
+
+	m := regexp.MustCompile(`^([-+]?[0-9][0-9_,]*(?:\.[0-9]+)?(?:[eE][-+]?[0-9]+)?|[-+]?\.[0-9]+)\s*(\S.*)$`).FindStringSubmatch(s)
+	if m == nil {
+		return 0, "", ErrNotAQuantity
+	}
+	value, err := strconv.ParseFloat(strings.NewReplacer("_", "", ",", "").Replace(m[1]), 64)
+	if err != nil {
+		return 0, "", ErrNotAQuantity
+	}
+
+	from, err := u.table.parseExpr(m[2])
+	if err != nil {
+		return 0, "", err
+	}
+
+	if to == "" {
+		to = from.show
+	}
+	if to == "" {
+		to = u.table.baseUnit(from.dims)
+	}
+
+	target := unitDef{factor: 1, dims: map[string]int{}}
+	if to != "" {
+		if target, err = u.table.parseExpr(to); err != nil {
+			return 0, "", err
+		}
+	}
+
+	if !sameDims(from.dims, target.dims) {
+		return 0, "", &IncompatibleUnitsError{
+			From:          strings.TrimSpace(m[2]),
+			To:            to,
+			FromDimension: u.table.describeDims(from.dims),
+			ToDimension:   u.table.describeDims(target.dims),
+		}
+	}
+
+	// Temperatures are written the same way whichever name was used for them,
+	// ie: 37°C for both --to=C and --to=celsius
+	if strings.HasPrefix(target.symbol, "°") {
+		to = target.symbol
+	}
+
+	base := (value + from.offset) * from.factor
+	return base/target.factor - target.offset, to, nil
+}
+
+// formatUnitValue writes the value with up to 6 significant digits, very big
+// and very small values are written with an exponent
+func formatUnitValue(v float64) string {
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'g', 6, 64), 64)
+	if rounded == 0 || (math.Abs(rounded) >= 1e-6 && math.Abs(rounded) < 1e15) {
+		return strconv.FormatFloat(rounded, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(rounded, 'g', -1, 64)
+}
@@ -0,0 +1,132 @@
+package parsers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUnitDoFromMachine(t *testing.T) {
+	tests := []struct {
+		to  string
+		in  string
+		out string
+		err error
+	}{
+		{"", "5mi", "8.04672 km", nil},
+		{"km", "5 miles", "8.04672 km", nil},
+		{"", "5 mi in m", "8046.72 m", nil},
+		{"", "98.6F", "37°C", nil},
+		{"", "-40 °C", "-40°F", nil},
+		{"K", "0 celsius", "273.15 K", nil},
+		// Temperatures are written the same way whichever name is asked for
+		{"C", "98.6F", "37°C", nil},
+		{"celsius", "98.6F", "37°C", nil},
+		{"", "37 C in F", "98.6°F", nil},
+		{"", "100 km/h", "27.7778 m/s", nil},
+		{"", "60 mph", "96.5606 km/h", nil},
+		{"km/h", "10 miles per hour", "16.0934 km/h", nil},
+		{"", "9.81 m/s^2", "9.81 m/s^2", nil},
+		{"m^2", "1 ha", "10000 m^2", nil},
+		{"L", "1 m³", "1000 L", nil},
+		{"", "1 kWh", "3600000 J", nil},
+		{"kWh", "3.6 MJ", "1 kWh", nil},
+		{"", "10 ms", "0.01 s", nil},
+		{"ns", "1 µs", "1000 ns", nil},
+		{"", "1,000 kg", "2204.62 lb", nil},
+		{"", "1 kilometer", "0.621371 mi", nil},
+		{"mm", "1 in", "25.4 mm", nil},
+		// Prefixes only go on units that take them
+		{"", "5 kmi", "", ErrUnknownUnit},
+		{"", "5 furlongs", "", ErrUnknownUnit},
+		{"", "five miles", "", ErrNotAQuantity},
+		{"", "5", "", ErrNotAQuantity},
+	}
+
+	table, _ := NewUnitTable()
+	for i, tt := range tests {
+		unit, _ := NewUnit(tt.to, table)
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := unit.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.in, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestUnitDoIntoMachine(t *testing.T) {
+	tests := []struct {
+		to  string
+		in  string
+		out string
+	}{
+		{"", "5mi", "8.04672"},
+		{"m", "5mi", "8046.72"},
+		{"", "212 F", "100"},
+	}
+
+	table, _ := NewUnitTable()
+	for i, tt := range tests {
+		unit, _ := NewUnit(tt.to, table)
+		t.Run(tt.in, func(t *testing.T) {
+			got, _ := unit.DoIntoMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+		})
+	}
+}
+
+func TestUnitIncompatible(t *testing.T) {
+	table, _ := NewUnitTable()
+	unit, _ := NewUnit("kg", table)
+
+	_, err := unit.DoFromMachine("5mi")
+	var incompatible *IncompatibleUnitsError
+	if !errors.As(err, &incompatible) {
+		t.Fatalf("want IncompatibleUnitsError ; got `%v`", err)
+	}
+	if incompatible.FromDimension != "length" || incompatible.ToDimension != "mass" {
+		t.Errorf("want length into mass ; got `%s` into `%s`", incompatible.FromDimension, incompatible.ToDimension)
+	}
+	if want := "Can't convert mi (length) into kg (mass)"; err.Error() != want {
+		t.Errorf("want `%s` ; got `%s`", want, err.Error())
+	}
+}
+
+func TestUnitTableDefinitions(t *testing.T) {
+	tests := []struct {
+		extra string
+		to    string
+		in    string
+		out   string
+		err   error
+	}{
+		{"furlong, furlongs = 201.168 m -> mi", "", "8 furlongs", "1 mi", nil},
+		{"# comments and blank lines\n\nfathom = 6 ft", "m", "1 fathom", "1.8288 m", nil},
+		{"mi = 1 km -> m", "", "5 mi", "5000 m", nil},
+		{"px = base pixels", "", "5 px", "5 px", nil},
+		{"wat", "", "", "", ErrBadUnitDefinition},
+		{"x = 5 nope", "", "", "", ErrBadUnitDefinition},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.extra, func(t *testing.T) {
+			table, err := NewUnitTable(tt.extra)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Error Case %d: Given = `%s` ; want `%v` ; got `%v`", i, tt.extra, tt.err, err)
+			}
+			if err != nil {
+				return
+			}
+			unit, _ := NewUnit(tt.to, table)
+			got, _ := unit.DoFromMachine(tt.in)
+			if got != tt.out {
+				t.Errorf("Case %d: Given = `%s` ; want `%s` ; got `%s`", i, tt.in, tt.out, got)
+			}
+		})
+	}
+}